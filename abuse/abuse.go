@@ -0,0 +1,69 @@
+// Package abuse implementa un pipeline de puntuación de spam/abuso para el
+// formulario de contacto: honeypot, verificación de captcha, listas DNSBL y
+// heurísticas de contenido. Cada Checker aporta puntos a un Result; si la
+// suma alcanza el umbral configurado, Evaluate marca la solicitud como
+// rechazada.
+package abuse
+
+import "context"
+
+// Input agrupa los datos de la solicitud que los Checker necesitan para
+// puntuarla.
+type Input struct {
+	Name    string
+	Email   string
+	Message string
+
+	// Website es el campo honeypot oculto: un usuario real nunca lo rellena.
+	Website string
+	// CaptchaToken es el token de hCaptcha/Turnstile resuelto por el cliente.
+	CaptchaToken string
+	ClientIP     string
+}
+
+// Result es el resultado de evaluar un Input contra todos los Checker
+// configurados en un Scorer.
+type Result struct {
+	Score    int
+	Reasons  []string
+	Rejected bool
+}
+
+// Checker puntúa un aspecto concreto de Input. points es la contribución al
+// Score total; reason describe el motivo y solo se usa cuando points > 0.
+// Un error indica que el check no pudo completarse (p. ej. un timeout de
+// DNS) y se descarta sin afectar al Score.
+type Checker interface {
+	Check(ctx context.Context, in Input) (points int, reason string, err error)
+}
+
+// Scorer ejecuta una lista de Checker y marca como rechazada cualquier
+// solicitud cuyo Score acumulado alcance Threshold.
+type Scorer struct {
+	Threshold int
+	Checkers  []Checker
+}
+
+// NewScorer crea un Scorer con el umbral y los checkers dados.
+func NewScorer(threshold int, checkers ...Checker) *Scorer {
+	return &Scorer{Threshold: threshold, Checkers: checkers}
+}
+
+// Evaluate ejecuta todos los Checker sobre in y acumula su puntuación.
+func (s *Scorer) Evaluate(ctx context.Context, in Input) Result {
+	var result Result
+
+	for _, checker := range s.Checkers {
+		points, reason, err := checker.Check(ctx, in)
+		if err != nil || points <= 0 {
+			continue
+		}
+		result.Score += points
+		if reason != "" {
+			result.Reasons = append(result.Reasons, reason)
+		}
+	}
+
+	result.Rejected = result.Score >= s.Threshold
+	return result
+}
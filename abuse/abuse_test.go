@@ -0,0 +1,76 @@
+package abuse
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHoneypotCheckerScoresFilledField(t *testing.T) {
+	checker := NewHoneypotChecker(10)
+
+	points, reason, err := checker.Check(context.Background(), Input{Website: "http://spam.example"})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if points != 10 || reason == "" {
+		t.Errorf("Check() con honeypot relleno = (%d, %q), want puntos > 0 y un motivo", points, reason)
+	}
+
+	points, _, err = checker.Check(context.Background(), Input{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if points != 0 {
+		t.Errorf("Check() con honeypot vacío = %d, want 0", points)
+	}
+}
+
+func TestContentHeuristicsCheckerDetectsURLsCyrillicAndRepetition(t *testing.T) {
+	checker := NewContentHeuristicsChecker(1, 5.0, 3)
+
+	points, reason, err := checker.Check(context.Background(), Input{
+		Message: "visita http://a.com y http://b.com y http://c.com привет aaaaaaaaaaaaaaaa",
+	})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if points == 0 || reason == "" {
+		t.Errorf("Check() con mensaje sospechoso = (%d, %q), want puntos > 0", points, reason)
+	}
+
+	points, _, err = checker.Check(context.Background(), Input{Message: "Hola, quisiera más información sobre sus servicios."})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if points != 0 {
+		t.Errorf("Check() con mensaje normal = %d, want 0", points)
+	}
+}
+
+func TestReverseIPv4(t *testing.T) {
+	got, err := reverseIPv4("1.2.3.4")
+	if err != nil {
+		t.Fatalf("reverseIPv4() error = %v", err)
+	}
+	if got != "4.3.2.1" {
+		t.Errorf("reverseIPv4() = %q, want %q", got, "4.3.2.1")
+	}
+
+	if _, err := reverseIPv4("not-an-ip"); err == nil {
+		t.Error("reverseIPv4() con IP inválida, want error")
+	}
+}
+
+func TestScorerEvaluateRejectsAboveThreshold(t *testing.T) {
+	scorer := NewScorer(5, NewHoneypotChecker(10))
+
+	result := scorer.Evaluate(context.Background(), Input{Website: "spam"})
+	if !result.Rejected {
+		t.Errorf("Evaluate() con honeypot relleno no rechazó la solicitud: %+v", result)
+	}
+
+	result = scorer.Evaluate(context.Background(), Input{})
+	if result.Rejected {
+		t.Errorf("Evaluate() con solicitud limpia rechazó indebidamente: %+v", result)
+	}
+}
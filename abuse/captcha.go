@@ -0,0 +1,109 @@
+package abuse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CaptchaProvider identifica contra qué servicio se valida CaptchaToken.
+type CaptchaProvider string
+
+const (
+	// ProviderHCaptcha verifica tokens contra hcaptcha.com/siteverify.
+	ProviderHCaptcha CaptchaProvider = "hcaptcha"
+	// ProviderTurnstile verifica tokens contra el siteverify de Cloudflare
+	// Turnstile.
+	ProviderTurnstile CaptchaProvider = "turnstile"
+)
+
+// siteverifyURL son los endpoints de verificación server-side de cada
+// proveedor soportado.
+var siteverifyURL = map[CaptchaProvider]string{
+	ProviderHCaptcha:  "https://hcaptcha.com/siteverify",
+	ProviderTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+const captchaVerifyTimeout = 5 * time.Second
+
+// siteverifyResponse es el subconjunto común de la respuesta JSON que
+// devuelven tanto hCaptcha como Turnstile.
+type siteverifyResponse struct {
+	Success bool `json:"success"`
+}
+
+// CaptchaChecker valida Input.CaptchaToken contra el endpoint siteverify de
+// Provider usando Secret. Si Secret está vacío el check queda deshabilitado
+// (no puntúa), para no romper despliegues que todavía no configuraron
+// captcha.
+type CaptchaChecker struct {
+	Provider CaptchaProvider
+	Secret   string
+	// Points es la puntuación aportada cuando el token falta o no es válido.
+	Points int
+
+	httpClient *http.Client
+}
+
+// NewCaptchaChecker crea un CaptchaChecker para provider, verificando
+// contra secret con la puntuación points para los tokens inválidos o
+// ausentes.
+func NewCaptchaChecker(provider CaptchaProvider, secret string, points int) *CaptchaChecker {
+	return &CaptchaChecker{
+		Provider:   provider,
+		Secret:     secret,
+		Points:     points,
+		httpClient: &http.Client{Timeout: captchaVerifyTimeout},
+	}
+}
+
+func (c *CaptchaChecker) Check(ctx context.Context, in Input) (int, string, error) {
+	if c.Secret == "" {
+		return 0, "", nil
+	}
+	if strings.TrimSpace(in.CaptchaToken) == "" {
+		return c.Points, "captcha ausente", nil
+	}
+
+	endpoint, ok := siteverifyURL[c.Provider]
+	if !ok {
+		return 0, "", fmt.Errorf("proveedor de captcha desconocido: %q", c.Provider)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, captchaVerifyTimeout)
+	defer cancel()
+
+	form := url.Values{
+		"secret":   {c.Secret},
+		"response": {in.CaptchaToken},
+	}
+	if in.ClientIP != "" {
+		form.Set("remoteip", in.ClientIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, "", fmt.Errorf("error al preparar la verificación de captcha: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("error al verificar el captcha: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result siteverifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, "", fmt.Errorf("error al leer la respuesta de siteverify: %v", err)
+	}
+
+	if !result.Success {
+		return c.Points, "captcha inválido", nil
+	}
+	return 0, "", nil
+}
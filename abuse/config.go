@@ -0,0 +1,56 @@
+package abuse
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultScoreThreshold    = 5
+	defaultHoneypotPoints    = 10
+	defaultCaptchaPoints     = 5
+	defaultDNSBLPoints       = 4
+	defaultHeuristicsPoints  = 2
+	defaultMaxURLs           = 2
+	defaultMaxRepetitionRate = 10.0
+)
+
+var defaultDNSBLZones = []string{"zen.spamhaus.org"}
+
+// FromEnv construye el Scorer usado por el formulario de contacto:
+// honeypot y heurísticas de contenido siempre activos, captcha habilitado
+// si CAPTCHA_SECRET está definida, y DNSBL contra las zonas de
+// DNSBL_ZONES (o zen.spamhaus.org por defecto).
+func FromEnv() *Scorer {
+	threshold := defaultScoreThreshold
+	if v := os.Getenv("ABUSE_SCORE_THRESHOLD"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			threshold = parsed
+		}
+	}
+
+	checkers := []Checker{
+		NewHoneypotChecker(defaultHoneypotPoints),
+		NewCaptchaChecker(CaptchaProvider(os.Getenv("CAPTCHA_PROVIDER")), os.Getenv("CAPTCHA_SECRET"), defaultCaptchaPoints),
+		NewDNSBLChecker(dnsblZonesFromEnv(), defaultDNSBLPoints),
+		NewContentHeuristicsChecker(defaultMaxURLs, defaultMaxRepetitionRate, defaultHeuristicsPoints),
+	}
+
+	return NewScorer(threshold, checkers...)
+}
+
+func dnsblZonesFromEnv() []string {
+	v := os.Getenv("DNSBL_ZONES")
+	if v == "" {
+		return defaultDNSBLZones
+	}
+
+	var zones []string
+	for _, zone := range strings.Split(v, ",") {
+		if zone = strings.TrimSpace(zone); zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
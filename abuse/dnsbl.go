@@ -0,0 +1,110 @@
+package abuse
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	dnsblLookupTimeout = 2 * time.Second
+	dnsblCacheTTL      = 10 * time.Minute
+)
+
+// dnsblCacheEntry guarda si una IP ya consultada está listada y hasta
+// cuándo ese resultado sigue siendo válido.
+type dnsblCacheEntry struct {
+	listed  bool
+	expires time.Time
+}
+
+// DNSBLChecker consulta si Input.ClientIP aparece listada en alguna de las
+// Zones (p. ej. "zen.spamhaus.org"), invirtiendo los octetos de la IP y
+// resolviendo "{ip-invertida}.{zona}" como registro A. El primer registro A
+// obtenido de cualquier zona marca la IP como listada. Los resultados se
+// cachean por IP durante dnsblCacheTTL para no repetir la consulta DNS en
+// cada solicitud de un mismo cliente.
+type DNSBLChecker struct {
+	Zones []string
+	// Points es la puntuación aportada cuando la IP aparece en alguna zona.
+	Points int
+
+	resolver *net.Resolver
+	cache    sync.Map // string (IP) -> dnsblCacheEntry
+}
+
+// NewDNSBLChecker crea un DNSBLChecker para las zonas dadas.
+func NewDNSBLChecker(zones []string, points int) *DNSBLChecker {
+	return &DNSBLChecker{
+		Zones:    zones,
+		Points:   points,
+		resolver: net.DefaultResolver,
+	}
+}
+
+func (c *DNSBLChecker) Check(ctx context.Context, in Input) (int, string, error) {
+	if len(c.Zones) == 0 || in.ClientIP == "" {
+		return 0, "", nil
+	}
+
+	reversed, err := reverseIPv4(in.ClientIP)
+	if err != nil {
+		// Las DNSBL solo cubren IPv4; una IPv6 o una IP inválida
+		// simplemente no se puntúa.
+		return 0, "", nil
+	}
+
+	if entry, ok := c.cache.Load(in.ClientIP); ok {
+		cached := entry.(dnsblCacheEntry)
+		if time.Now().Before(cached.expires) {
+			if cached.listed {
+				return c.Points, "IP listada en DNSBL", nil
+			}
+			return 0, "", nil
+		}
+	}
+
+	listed, zone := c.lookup(ctx, reversed)
+	c.cache.Store(in.ClientIP, dnsblCacheEntry{listed: listed, expires: time.Now().Add(dnsblCacheTTL)})
+
+	if listed {
+		return c.Points, fmt.Sprintf("IP listada en %s", zone), nil
+	}
+	return 0, "", nil
+}
+
+// lookup consulta reversed.zone para cada zona configurada y devuelve la
+// primera que resuelva algún registro A.
+func (c *DNSBLChecker) lookup(ctx context.Context, reversed string) (listed bool, zone string) {
+	for _, zone := range c.Zones {
+		lookupCtx, cancel := context.WithTimeout(ctx, dnsblLookupTimeout)
+		addrs, err := c.resolver.LookupHost(lookupCtx, reversed+"."+zone)
+		cancel()
+		if err == nil && len(addrs) > 0 {
+			return true, zone
+		}
+	}
+	return false, ""
+}
+
+// reverseIPv4 invierte los octetos de una dirección IPv4 ("1.2.3.4" ->
+// "4.3.2.1"), como exige el esquema de consulta de las DNSBL.
+func reverseIPv4(ip string) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", fmt.Errorf("IP inválida: %q", ip)
+	}
+	v4 := parsed.To4()
+	if v4 == nil {
+		return "", fmt.Errorf("las DNSBL solo soportan IPv4: %q", ip)
+	}
+
+	octets := strings.Split(v4.String(), ".")
+	for i, j := 0, len(octets)-1; i < j; i, j = i+1, j-1 {
+		octets[i], octets[j] = octets[j], octets[i]
+	}
+	return strings.Join(octets, "."), nil
+}
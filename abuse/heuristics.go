@@ -0,0 +1,102 @@
+package abuse
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+var urlRegex = regexp.MustCompile(`https?://`)
+
+// ContentHeuristicsChecker puntúa el mensaje por rasgos típicos de spam:
+// demasiadas URLs, caracteres cirílicos o de ancho cero (usados para evadir
+// filtros de palabras), y un ratio de repetición de caracteres alto (texto
+// generado repitiendo la misma frase).
+type ContentHeuristicsChecker struct {
+	// MaxURLs es el número máximo de URLs tolerado; el mensaje puntúa solo
+	// si contiene más.
+	MaxURLs int
+	// MaxRepetitionRatio es el ratio máximo tolerado de (longitud del
+	// mensaje) / (caracteres distintos); por encima, se considera texto
+	// repetitivo generado automáticamente.
+	MaxRepetitionRatio float64
+	// Points es la puntuación aportada por cada heurística que dispara.
+	Points int
+}
+
+// NewContentHeuristicsChecker crea un ContentHeuristicsChecker con los
+// umbrales dados.
+func NewContentHeuristicsChecker(maxURLs int, maxRepetitionRatio float64, points int) ContentHeuristicsChecker {
+	return ContentHeuristicsChecker{MaxURLs: maxURLs, MaxRepetitionRatio: maxRepetitionRatio, Points: points}
+}
+
+func (c ContentHeuristicsChecker) Check(_ context.Context, in Input) (int, string, error) {
+	var points int
+	var reasons []string
+
+	if urls := urlRegex.FindAllStringIndex(in.Message, -1); len(urls) > c.MaxURLs {
+		points += c.Points
+		reasons = append(reasons, "demasiadas URLs")
+	}
+
+	if hasCyrillic(in.Message) {
+		points += c.Points
+		reasons = append(reasons, "caracteres cirílicos")
+	}
+
+	if hasZeroWidth(in.Message) {
+		points += c.Points
+		reasons = append(reasons, "caracteres de ancho cero")
+	}
+
+	if ratio := repetitionRatio(in.Message); ratio > c.MaxRepetitionRatio {
+		points += c.Points
+		reasons = append(reasons, "texto repetitivo")
+	}
+
+	return points, strings.Join(reasons, ", "), nil
+}
+
+func hasCyrillic(s string) bool {
+	for _, r := range s {
+		if r >= 0x0400 && r <= 0x04FF {
+			return true
+		}
+	}
+	return false
+}
+
+// zeroWidthChars son caracteres invisibles habituales para ofuscar palabras
+// clave de un filtro: ZERO WIDTH SPACE/NON-JOINER/JOINER y BOM.
+var zeroWidthChars = map[rune]bool{
+	'​': true, // ZERO WIDTH SPACE
+	'‌': true, // ZERO WIDTH NON-JOINER
+	'‍': true, // ZERO WIDTH JOINER
+	'\uFEFF': true, // BYTE ORDER MARK
+}
+
+func hasZeroWidth(s string) bool {
+	for _, r := range s {
+		if zeroWidthChars[r] {
+			return true
+		}
+	}
+	return false
+}
+
+// repetitionRatio devuelve cuántas veces se repite, en promedio, cada
+// carácter distinto del mensaje. Un mensaje con poca variedad (la misma
+// frase repetida muchas veces) da un ratio alto.
+func repetitionRatio(s string) float64 {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return 0
+	}
+
+	distinct := make(map[rune]struct{})
+	for _, r := range runes {
+		distinct[r] = struct{}{}
+	}
+
+	return float64(len(runes)) / float64(len(distinct))
+}
@@ -0,0 +1,25 @@
+package abuse
+
+import "context"
+
+// HoneypotChecker rechaza cualquier solicitud que rellene el campo oculto
+// Website: un usuario real nunca lo ve ni lo completa, así que solo lo
+// hacen los bots que rellenan todos los campos del formulario.
+type HoneypotChecker struct {
+	// Points es la puntuación aportada cuando el honeypot viene relleno. Por
+	// sí sola debería superar cualquier umbral razonable del Scorer.
+	Points int
+}
+
+// NewHoneypotChecker crea un HoneypotChecker que aporta points cuando el
+// campo Website viene relleno.
+func NewHoneypotChecker(points int) HoneypotChecker {
+	return HoneypotChecker{Points: points}
+}
+
+func (c HoneypotChecker) Check(_ context.Context, in Input) (int, string, error) {
+	if in.Website != "" {
+		return c.Points, "honeypot relleno", nil
+	}
+	return 0, "", nil
+}
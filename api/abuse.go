@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jhonatangrajales/softex-lab/abuse"
+	"github.com/jhonatangrajales/softex-lab/internal/logx"
+)
+
+var (
+	abuseScorerOnce sync.Once
+	abuseScorer     *abuse.Scorer
+)
+
+// getAbuseScorer devuelve el abuse.Scorer compartido, construido desde las
+// variables de entorno la primera vez que se necesita.
+func getAbuseScorer() *abuse.Scorer {
+	abuseScorerOnce.Do(func() {
+		abuseScorer = abuse.FromEnv()
+	})
+	return abuseScorer
+}
+
+// checkAbuseScore evalúa data con el pipeline de abuse.Scorer antes de
+// intentar enviar el correo. Si el score alcanza el umbral configurado,
+// devuelve un error genérico (sin revelar los motivos al remitente) y
+// registra las razones para su análisis.
+func checkAbuseScore(ctx context.Context, data ContactData, clientIP string) error {
+	result := getAbuseScorer().Evaluate(ctx, abuse.Input{
+		Name:         data.Name,
+		Email:        data.Email,
+		Message:      data.Message,
+		Website:      data.Website,
+		CaptchaToken: data.CaptchaToken,
+		ClientIP:     clientIP,
+	})
+
+	if result.Rejected {
+		getLogger().Warn(ctx, "solicitud rechazada por score de abuso", logx.Fields{
+			"client_ip": clientIP,
+			"score":     result.Score,
+			"reasons":   strings.Join(result.Reasons, ", "),
+		})
+		return fmt.Errorf("no pudimos procesar tu mensaje, intenta de nuevo más tarde")
+	}
+
+	return nil
+}
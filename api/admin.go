@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+)
+
+// EmailTestRequest representa el payload aceptado por EmailTestHandler.
+type EmailTestRequest struct {
+	To string `json:"to"`
+}
+
+// EmailTestHandler permite a los administradores validar en caliente la
+// configuración SMTP (auth, TLS, DNS) sin tener que esperar a que llegue un
+// envío real del formulario de contacto. Reutiliza la misma autenticación
+// X-Admin-Key que AnalyticsHandler.
+func EmailTestHandler(w http.ResponseWriter, r *http.Request) {
+	adminKey := os.Getenv("ADMIN_KEY")
+	if adminKey == "" || r.Header.Get("X-Admin-Key") != adminKey {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req EmailTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.To == "" {
+		sendJSONError(w, "se requiere un destinatario \"to\" válido", http.StatusBadRequest)
+		return
+	}
+
+	config, err := newSmtpConfig()
+	if err != nil {
+		sendJSONError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	config.ToEmail = req.To
+
+	testData := ContactData{
+		Name:    "Admin",
+		Email:   config.User,
+		Message: "Este es un correo de prueba enviado desde /api/admin/email/test para validar la configuración SMTP.",
+	}
+
+	if err := sendEmail(config, testData, "admin-test"); err != nil {
+		sendJSONError(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	substore "github.com/jhonatangrajales/softex-lab/store"
+)
+
+// verifyAdminToken comprueba la cabecera "Authorization: Bearer <token>"
+// contra ADMIN_TOKEN con una comparación en tiempo constante, igual que
+// verifySlackSignature hace para la firma de Slack.
+func verifyAdminToken(r *http.Request) bool {
+	adminToken := os.Getenv("ADMIN_TOKEN")
+	if adminToken == "" {
+		return false
+	}
+
+	got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(got), []byte(adminToken)) == 1
+}
+
+// AdminSubmissionsHandler expone GET /admin/submissions?since=&q=&limit= y
+// GET /admin/submissions/{id}, protegido por un bearer token ADMIN_TOKEN,
+// para listar o consultar las submisiones persistidas del formulario de
+// contacto.
+func AdminSubmissionsHandler(w http.ResponseWriter, r *http.Request) {
+	if !verifyAdminToken(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id, ok := submissionIDFromPath(r.URL.Path); ok {
+		getSubmission(w, id)
+		return
+	}
+
+	listSubmissions(w, r.URL.Query())
+}
+
+func listSubmissions(w http.ResponseWriter, query url.Values) {
+	filter := submissionFilterFromQuery(query)
+
+	submissions, err := getSubmissionStore().List(filter)
+	if err != nil {
+		sendJSONError(w, "error al listar submissions: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(submissions)
+}
+
+func getSubmission(w http.ResponseWriter, id int64) {
+	submission, err := getSubmissionStore().Get(id)
+	if err == substore.ErrNotFound {
+		sendJSONError(w, "submission no encontrada", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "error al obtener la submission: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(submission)
+}
+
+// submissionIDFromPath extrae el {id} final de rutas como
+// "/admin/submissions/42". Devuelve ok=false para "/admin/submissions" sin
+// segmento adicional, que se trata como una petición de listado.
+func submissionIDFromPath(p string) (id int64, ok bool) {
+	last := path.Base(p)
+	if last == "" || last == "submissions" {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// submissionFilterFromQuery traduce los parámetros since/q/limit de la
+// query string al store.Filter usado por List.
+func submissionFilterFromQuery(query url.Values) substore.Filter {
+	filter := substore.Filter{}
+
+	if since := query.Get("since"); since != "" {
+		if parsed, err := time.Parse(time.RFC3339, since); err == nil {
+			filter.Since = parsed
+		}
+	}
+	filter.Query = query.Get("q")
+	if limit := query.Get("limit"); limit != "" {
+		if parsed, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = parsed
+		}
+	}
+
+	return filter
+}
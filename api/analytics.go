@@ -1,22 +1,25 @@
-package api
+package handler
 
 import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 )
 
 // Analytics representa datos de analytics del formulario
 type Analytics struct {
-	TotalSubmissions int64                    `json:"total_submissions"`
-	SuccessRate      float64                  `json:"success_rate"`
-	TopCountries     map[string]int           `json:"top_countries"`
-	HourlyStats      map[string]int           `json:"hourly_stats"`
-	LastUpdated      time.Time                `json:"last_updated"`
-	ErrorStats       map[string]int           `json:"error_stats"`
+	TotalSubmissions int64          `json:"total_submissions"`
+	SuccessRate      float64        `json:"success_rate"`
+	TopCountries     map[string]int `json:"top_countries"`
+	HourlyStats      map[string]int `json:"hourly_stats"`
+	LastUpdated      time.Time      `json:"last_updated"`
+	ErrorStats       map[string]int `json:"error_stats"`
 }
 
 // FormSubmission representa una submisión del formulario para analytics
@@ -27,11 +30,40 @@ type FormSubmission struct {
 	Error     string    `json:"error,omitempty"`
 }
 
+const defaultRetentionDays = 90
+
 var (
-	submissions []FormSubmission
-	analytics   Analytics
+	storeOnce sync.Once
+	store     SubmissionStore
 )
 
+// getStore abre perezosamente el SubmissionStore usado por el proceso.
+// La ruta y la retención se configuran por variables de entorno para no
+// tener que recompilar entre despliegues.
+func getStore() SubmissionStore {
+	storeOnce.Do(func() {
+		path := os.Getenv("ANALYTICS_DB_PATH")
+		if path == "" {
+			path = "analytics.db"
+		}
+
+		retentionDays := defaultRetentionDays
+		if v := os.Getenv("ANALYTICS_RETENTION_DAYS"); v != "" {
+			if parsed, err := strconv.Atoi(v); err == nil {
+				retentionDays = parsed
+			}
+		}
+
+		s, err := NewSQLiteStore(path, retentionDays)
+		if err != nil {
+			log.Fatalf("Error al inicializar el SubmissionStore: %v", err)
+		}
+		store = s
+		ensureDigestScheduler()
+	})
+	return store
+}
+
 // Función para registrar una submisión
 func recordSubmission(success bool, country, errorMsg string) {
 	submission := FormSubmission{
@@ -40,42 +72,10 @@ func recordSubmission(success bool, country, errorMsg string) {
 		Country:   country,
 		Error:     errorMsg,
 	}
-	
-	submissions = append(submissions, submission)
-	updateAnalytics()
-}
-
-// Actualizar estadísticas de analytics
-func updateAnalytics() {
-	if len(submissions) == 0 {
-		return
-	}
 
-	analytics.TotalSubmissions = int64(len(submissions))
-	analytics.LastUpdated = time.Now()
-	
-	// Calcular tasa de éxito
-	successCount := 0
-	countryCount := make(map[string]int)
-	hourlyCount := make(map[string]int)
-	errorCount := make(map[string]int)
-	
-	for _, sub := range submissions {
-		if sub.Success {
-			successCount++
-		} else if sub.Error != "" {
-			errorCount[sub.Error]++
-		}
-		
-		countryCount[sub.Country]++
-		hour := sub.Timestamp.Format("15")
-		hourlyCount[hour]++
+	if err := getStore().Record(submission); err != nil {
+		log.Printf("Error al registrar submisión: %v", err)
 	}
-	
-	analytics.SuccessRate = float64(successCount) / float64(len(submissions)) * 100
-	analytics.TopCountries = countryCount
-	analytics.HourlyStats = hourlyCount
-	analytics.ErrorStats = errorCount
 }
 
 // Handler para obtener analytics (solo para admin)
@@ -92,9 +92,16 @@ func AnalyticsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	analytics, err := getStore().Query(time.Time{}, time.Now())
+	if err != nil {
+		log.Printf("Error querying analytics: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	
+
 	if err := json.NewEncoder(w).Encode(analytics); err != nil {
 		log.Printf("Error encoding analytics: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -102,34 +109,38 @@ func AnalyticsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Función para obtener país desde IP (simulado)
+// Función para obtener país desde IP usando la base de datos GeoLite2-Country
+// cargada por getGeoResolver.
 func getCountryFromIP(ip string) string {
-	// En producción, usar un servicio como MaxMind GeoIP
-	if ip == "127.0.0.1" || ip == "::1" {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "Unknown"
+	}
+	if parsed.IsLoopback() {
 		return "Local"
 	}
-	return "Unknown"
+	return getGeoResolver().Country(parsed)
 }
 
 // Modificar el Handler principal para incluir analytics
 func HandlerWithAnalytics(w http.ResponseWriter, r *http.Request) {
 	clientIP := getClientIP(r)
 	country := getCountryFromIP(clientIP)
-	
+
 	// Llamar al handler original
 	originalHandler := http.HandlerFunc(Handler)
-	
+
 	// Crear un ResponseWriter personalizado para capturar el status
 	rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 	originalHandler.ServeHTTP(rw, r)
-	
+
 	// Registrar la submisión para analytics
 	success := rw.statusCode == http.StatusOK
 	errorMsg := ""
 	if !success {
 		errorMsg = fmt.Sprintf("HTTP %d", rw.statusCode)
 	}
-	
+
 	recordSubmission(success, country, errorMsg)
 }
 
@@ -142,4 +153,4 @@ type responseWriter struct {
 func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
-}
\ No newline at end of file
+}
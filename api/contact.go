@@ -1,19 +1,20 @@
 package handler
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"html"
-	"log"
+	"net"
 	"net/http"
-	"net/smtp"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 	"unicode"
+
+	"github.com/jhonatangrajales/softex-lab/internal/logx"
+	"github.com/jhonatangrajales/softex-lab/internal/mailer"
 )
 
 // ContactData representa los datos del formulario de contacto
@@ -21,6 +22,14 @@ type ContactData struct {
 	Name    string `json:"name"`
 	Email   string `json:"email"`
 	Message string `json:"message"`
+
+	// Website es un campo honeypot oculto en el formulario: un usuario real
+	// nunca lo rellena, así que si llega con contenido se trata como spam.
+	Website string `json:"website,omitempty"`
+	// CaptchaToken es el token de hCaptcha/Turnstile resuelto por el
+	// cliente, verificado contra el proveedor de CAPTCHA_PROVIDER usando
+	// CAPTCHA_SECRET.
+	CaptchaToken string `json:"captcha_token,omitempty"`
 }
 
 // SmtpConfig contiene la configuración SMTP
@@ -38,22 +47,6 @@ type APIResponse struct {
 	Message string `json:"message"`
 }
 
-// Rate limiting
-type requestInfo struct {
-	count     int
-	firstSeen time.Time
-}
-
-var (
-	visitors = make(map[string]*requestInfo)
-	mu       sync.Mutex
-)
-
-const (
-	maxRequests = 3
-	timeWindow  = 5 * time.Minute
-)
-
 // Función para obtener la configuración SMTP desde variables de entorno
 func newSmtpConfig() (SmtpConfig, error) {
 	config := SmtpConfig{
@@ -135,55 +128,72 @@ func sanitizeInput(input string) string {
 	return strings.TrimSpace(cleaned)
 }
 
-// Función para verificar rate limiting
-func checkRateLimit(clientIP string) error {
-	mu.Lock()
-	defer mu.Unlock()
-
-	now := time.Now()
-
-	// Limpiar entradas antiguas
-	for ip, info := range visitors {
-		if now.Sub(info.firstSeen) > timeWindow {
-			delete(visitors, ip)
-		}
+// Función para verificar rate limiting. Delega en el RateLimiter
+// configurado (token bucket en memoria o en Redis, según
+// RATE_LIMIT_BACKEND) y devuelve el resultado para que el handler pueda
+// exponer Retry-After y X-RateLimit-Remaining.
+func checkRateLimit(clientIP string) (RateLimitResult, error) {
+	result, err := getRateLimiter().Allow(clientIP)
+	if err != nil {
+		return result, fmt.Errorf("error al verificar el límite de solicitudes: %v", err)
 	}
-
-	// Verificar límite para esta IP
-	if info, exists := visitors[clientIP]; exists {
-		if info.count >= maxRequests {
-			return fmt.Errorf("demasiadas solicitudes. Intenta de nuevo en %v", timeWindow-now.Sub(info.firstSeen))
-		}
-		info.count++
-	} else {
-		visitors[clientIP] = &requestInfo{
-			count:     1,
-			firstSeen: now,
-		}
+	if !result.Allowed {
+		return result, fmt.Errorf("demasiadas solicitudes. Intenta de nuevo en %v", result.RetryAfter.Round(time.Second))
 	}
-
-	return nil
+	return result, nil
 }
 
 // Función para obtener la IP del cliente
 func getClientIP(r *http.Request) string {
-	// Verificar headers de proxy
+	remoteIP := r.RemoteAddr
+	if colon := strings.LastIndex(remoteIP, ":"); colon != -1 {
+		remoteIP = remoteIP[:colon]
+	}
+
+	// Solo confiar en las cabeceras de proxy si la IP que nos habla
+	// directamente está en TRUSTED_PROXIES; de lo contrario cualquier
+	// cliente podría suplantar su IP real vía X-Forwarded-For.
+	if !isTrustedProxy(remoteIP) {
+		return remoteIP
+	}
+
 	if ip := r.Header.Get("CF-Connecting-IP"); ip != "" {
 		return ip
 	}
 	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
-		return strings.Split(ip, ",")[0]
+		return strings.TrimSpace(strings.Split(ip, ",")[0])
 	}
 	if ip := r.Header.Get("X-Real-IP"); ip != "" {
 		return ip
 	}
 
-	// Fallback a RemoteAddr
-	ip := r.RemoteAddr
-	if colon := strings.LastIndex(ip, ":"); colon != -1 {
-		ip = ip[:colon]
+	return remoteIP
+}
+
+// isTrustedProxy informa si remoteIP pertenece a alguno de los CIDR listados
+// en TRUSTED_PROXIES (separados por comas). Si la variable no está definida
+// no se confía en ningún proxy, para no romper despliegues existentes.
+func isTrustedProxy(remoteIP string) bool {
+	cidrs := os.Getenv("TRUSTED_PROXIES")
+	if cidrs == "" {
+		return false
 	}
-	return ip
+
+	ip := net.ParseIP(remoteIP)
+	if ip == nil {
+		return false
+	}
+
+	for _, cidr := range strings.Split(cidrs, ",") {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			continue
+		}
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
 }
 
 // Función para formatear el cuerpo del email
@@ -246,7 +256,9 @@ func formatEmailBody(data ContactData, clientIP string) (string, error) {
 		strings.ReplaceAll(data.Message, "\n", "<br>")), nil
 }
 
-// Función para enviar email
+// Función para enviar email. Delega la construcción del MIME (texto +
+// HTML, adjuntos, firma DKIM opcional) y el transporte SMTP en el paquete
+// internal/mailer.
 func sendEmail(config SmtpConfig, data ContactData, clientIP string) error {
 	subject := fmt.Sprintf("Nuevo mensaje de contacto de %s", data.Name)
 
@@ -255,66 +267,36 @@ func sendEmail(config SmtpConfig, data ContactData, clientIP string) error {
 		return fmt.Errorf("error al formatear el cuerpo del email: %v", err)
 	}
 
-	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s",
-		config.User, config.ToEmail, subject, body)
-
-	auth := smtp.PlainAuth("", config.User, config.Pass, config.Host)
-	addr := fmt.Sprintf("%s:%s", config.Host, config.Port)
-
-	// Configurar TLS
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false,
-		ServerName:         config.Host,
-	}
-
-	conn, err := tls.Dial("tcp", addr, tlsConfig)
-	if err != nil {
-		return fmt.Errorf("error al conectar con TLS: %v", err)
-	}
-	defer conn.Close()
-
-	client, err := smtp.NewClient(conn, config.Host)
-	if err != nil {
-		return fmt.Errorf("error al crear cliente SMTP: %v", err)
-	}
-	defer func() {
-		if err := client.Quit(); err != nil {
-			log.Printf("Error al cerrar cliente SMTP: %v", err)
-		}
-	}()
-
-	if err = client.Auth(auth); err != nil {
-		return fmt.Errorf("error de autenticación SMTP: %v", err)
-	}
-
-	if err = client.Mail(config.User); err != nil {
-		return fmt.Errorf("error al establecer remitente: %v", err)
-	}
-
-	if err = client.Rcpt(config.ToEmail); err != nil {
-		return fmt.Errorf("error al establecer destinatario: %v", err)
-	}
-
-	writer, err := client.Data()
-	if err != nil {
-		return fmt.Errorf("error al iniciar datos: %v", err)
-	}
-
-	_, err = writer.Write([]byte(msg))
-	if err != nil {
-		return fmt.Errorf("error al escribir mensaje: %v", err)
-	}
+	msg := mailer.Message{
+		From:    config.User,
+		To:      []string{config.ToEmail},
+		ReplyTo: data.Email,
+		Subject: subject,
+		HTML:    body,
+	}
+
+	m := mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host: config.Host,
+		Port: config.Port,
+		User: config.User,
+		Pass: config.Pass,
+		DKIM: mailer.DKIMConfig{
+			PrivateKeyPath: os.Getenv("DKIM_PRIVATE_KEY_PATH"),
+			Domain:         os.Getenv("DKIM_DOMAIN"),
+			Selector:       os.Getenv("DKIM_SELECTOR"),
+		},
+		TLSMode: mailer.TLSMode(os.Getenv("SMTP_TLS_MODE")),
+	})
 
-	err = writer.Close()
-	if err != nil {
-		return fmt.Errorf("error al cerrar escritor: %v", err)
+	if err := m.Send(msg); err != nil {
+		return fmt.Errorf("error al enviar el correo: %v", err)
 	}
 
 	return nil
 }
 
 // Función para parsear y validar la request
-func parseAndValidateRequest(r *http.Request) (ContactData, error) {
+func parseAndValidateRequest(r *http.Request, clientIP string) (ContactData, error) {
 	var data ContactData
 
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
@@ -325,6 +307,10 @@ func parseAndValidateRequest(r *http.Request) (ContactData, error) {
 		return data, err
 	}
 
+	if err := checkAbuseScore(r.Context(), data, clientIP); err != nil {
+		return data, err
+	}
+
 	return data, nil
 }
 
@@ -351,8 +337,9 @@ func sendJSONSuccess(w http.ResponseWriter, message string) {
 func Contact(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 	clientIP := getClientIP(r)
+	ctx := logx.WithCorrelationID(r.Context(), requestCorrelationID(r))
 
-	log.Printf("Solicitud recibida - Método: %s, IP: %s", r.Method, clientIP)
+	getLogger().Info(ctx, "solicitud recibida", logx.Fields{"method": r.Method, "client_ip": clientIP})
 
 	// Configuración de CORS más flexible para manejar www y sin www
 	// Permitir todos los orígenes temporalmente para resolver el problema
@@ -367,8 +354,11 @@ func Contact(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Rate limiting
-	if err := checkRateLimit(clientIP); err != nil {
-		log.Printf("Rate limit excedido para IP %s: %v", clientIP, err)
+	rateLimit, err := checkRateLimit(clientIP)
+	w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(rateLimit.Remaining, 10))
+	if err != nil {
+		getLogger().Warn(ctx, "rate limit excedido", logx.Fields{"client_ip": clientIP, "status": http.StatusTooManyRequests})
+		w.Header().Set("Retry-After", strconv.Itoa(int(rateLimit.RetryAfter.Round(time.Second).Seconds())))
 		sendJSONError(w, err.Error(), http.StatusTooManyRequests)
 		return
 	}
@@ -378,18 +368,19 @@ func Contact(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parsear y validar
-	data, err := parseAndValidateRequest(r)
+	// Parsear, validar y puntuar abuso
+	data, err := parseAndValidateRequest(r, clientIP)
 	if err != nil {
-		log.Printf("Error de validación para IP %s: %v", clientIP, err)
+		getLogger().Info(ctx, "error de validación", logx.Fields{"client_ip": clientIP, "status": http.StatusBadRequest, "error": err.Error()})
 		sendJSONError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	getLogger().DebugPII(ctx, "datos de contacto recibidos", logx.Fields{"name": data.Name, "email": data.Email, "message": data.Message})
 
 	// Configuración SMTP
 	config, err := newSmtpConfig()
 	if err != nil {
-		log.Printf("Error de configuración SMTP: %v", err)
+		getLogger().Error(ctx, "error de configuración SMTP", logx.Fields{"status": http.StatusInternalServerError})
 		sendJSONError(w, "Error de configuración del servidor. Por favor, contacta al administrador.", http.StatusInternalServerError)
 		return
 	}
@@ -397,7 +388,7 @@ func Contact(w http.ResponseWriter, r *http.Request) {
 	// Enviar email
 	err = sendEmail(config, data, clientIP)
 	if err != nil {
-		log.Printf("Error al enviar correo para IP %s: %v", clientIP, err)
+		getLogger().Error(ctx, "error al enviar correo", logx.Fields{"client_ip": clientIP, "error": err.Error()})
 		// Mensaje más amigable para el usuario
 		if strings.Contains(err.Error(), "TLS handshake") || strings.Contains(err.Error(), "connection") {
 			   sendJSONError(w, "Error de conexión con el servidor de correo. Por favor, intenta de nuevo en unos minutos o contacta directamente a info@softexlab.com", http.StatusServiceUnavailable)
@@ -409,8 +400,14 @@ func Contact(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	recordContactSubmission(data, clientIP, r.Header.Get("User-Agent"), r.Header.Get("Origin"))
+
 	duration := time.Since(startTime)
-	log.Printf("Correo enviado exitosamente - IP: %s, Duración: %v", clientIP, duration)
+	getLogger().Info(ctx, "correo enviado exitosamente", logx.Fields{
+		"client_ip":   clientIP,
+		"status":      http.StatusOK,
+		"duration_ms": duration.Milliseconds(),
+	})
 
 	sendJSONSuccess(w, "¡Mensaje enviado con éxito! Te responderemos pronto.")
 }
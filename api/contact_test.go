@@ -1,15 +1,15 @@
-package api
+package handler
 
 import (
 	"strings"
+	"sync"
 	"testing"
 )
 
 // Helper para resetear el estado del rate limiter entre tests.
 func resetRateLimiter() {
-	mu.Lock()
-	defer mu.Unlock()
-	visitors = make(map[string]*requestInfo)
+	rateLimiterOnce = sync.Once{}
+	rateLimiter = nil
 }
 
 func TestNewSmtpConfig(t *testing.T) {
@@ -154,21 +154,30 @@ func TestFormatEmailBody(t *testing.T) {
 }
 
 func TestRateLimit(t *testing.T) {
+	t.Setenv("RATE_LIMIT_BACKEND", "")
+	t.Setenv("RATE_LIMIT_CAPACITY", "3")
+	t.Setenv("RATE_LIMIT_REFILL_PER_MIN", "0.01")
 	resetRateLimiter()
 
 	clientIP := "192.168.1.100"
 
 	for i := 0; i < 3; i++ {
-		err := checkRateLimit(clientIP)
+		result, err := checkRateLimit(clientIP)
 		if err != nil {
 			t.Errorf("Solicitud %d debería haber pasado, pero obtuvo error: %v", i+1, err)
 		}
+		if !result.Allowed {
+			t.Errorf("Solicitud %d debería haber sido permitida", i+1)
+		}
 	}
 
-	err := checkRateLimit(clientIP)
+	result, err := checkRateLimit(clientIP)
 	if err == nil {
 		t.Error("La 4ta solicitud debería haber fallado por rate limit")
 	}
+	if result.Allowed {
+		t.Error("La 4ta solicitud no debería haber sido permitida")
+	}
 }
 
 func TestSanitizeInput(t *testing.T) {
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+const defaultDigestInterval = 24 * time.Hour
+
+// digestData es el contexto pasado a las plantillas del digest.
+type digestData struct {
+	From time.Time
+	To   time.Time
+	Analytics
+}
+
+const digestTextTpl = `Resumen de actividad de contacto - Softex Labs
+Periodo: {{.From.Format "2006-01-02 15:04"}} a {{.To.Format "2006-01-02 15:04"}}
+
+Total de envíos: {{.TotalSubmissions}}
+Tasa de éxito: {{printf "%.1f" .SuccessRate}}%
+
+Países:
+{{range $country, $count := .TopCountries}}  - {{$country}}: {{$count}}
+{{end}}
+Horas con más actividad:
+{{range $hour, $count := .HourlyStats}}  - {{$hour}}h: {{$count}}
+{{end}}
+Errores más frecuentes:
+{{range $err, $count := .ErrorStats}}  - {{$err}}: {{$count}}
+{{end}}`
+
+const digestHTMLTpl = `<!DOCTYPE html>
+<html lang="es">
+<head><meta charset="UTF-8"><title>Resumen de actividad de contacto</title></head>
+<body style="font-family: Arial, sans-serif; background-color: #f4f4f4; padding: 20px;">
+  <div style="max-width: 600px; margin: 0 auto; background: white; padding: 30px; border-radius: 8px;">
+    <h2 style="color: #4f46e5;">Resumen de actividad de contacto</h2>
+    <p>Periodo: {{.From.Format "2006-01-02 15:04"}} a {{.To.Format "2006-01-02 15:04"}}</p>
+    <p><strong>Total de envíos:</strong> {{.TotalSubmissions}}</p>
+    <p><strong>Tasa de éxito:</strong> {{printf "%.1f" .SuccessRate}}%</p>
+    <h3>Países</h3>
+    <ul>{{range $country, $count := .TopCountries}}<li>{{$country}}: {{$count}}</li>{{end}}</ul>
+    <h3>Horas con más actividad</h3>
+    <ul>{{range $hour, $count := .HourlyStats}}<li>{{$hour}}h: {{$count}}</li>{{end}}</ul>
+    <h3>Errores más frecuentes</h3>
+    <ul>{{range $err, $count := .ErrorStats}}<li>{{$err}}: {{$count}}</li>{{end}}</ul>
+  </div>
+</body>
+</html>`
+
+var (
+	digestTextTemplate = texttemplate.Must(texttemplate.New("digest_text").Parse(digestTextTpl))
+	digestHTMLTemplate = template.Must(template.New("digest_html").Parse(digestHTMLTpl))
+)
+
+var digestOnce sync.Once
+
+// ensureDigestScheduler arranca, la primera vez que se invoca, el goroutine
+// que dispara el digest periódicamente. Se engancha desde getStore() para no
+// necesitar un punto de arranque dedicado en este paquete de handlers.
+func ensureDigestScheduler() {
+	digestOnce.Do(func() {
+		go digestScheduler()
+	})
+}
+
+// digestScheduler dispara runDigest una vez por DIGEST_INTERVAL (por
+// defecto 24h), igual que cleanupVisitors hace con el rate limiter.
+func digestScheduler() {
+	interval := digestInterval()
+	for {
+		time.Sleep(interval)
+		if err := runDigest(); err != nil {
+			log.Printf("Error al ejecutar el digest: %v", err)
+		}
+	}
+}
+
+func digestInterval() time.Duration {
+	if v := os.Getenv("DIGEST_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil {
+			return parsed
+		}
+	}
+	return defaultDigestInterval
+}
+
+func digestRecipients() []string {
+	raw := os.Getenv("DIGEST_TO")
+	if raw == "" {
+		return nil
+	}
+	var recipients []string
+	for _, r := range strings.Split(raw, ",") {
+		r = strings.TrimSpace(r)
+		if r != "" {
+			recipients = append(recipients, r)
+		}
+	}
+	return recipients
+}
+
+// runDigest recopila las submisiones registradas en la ventana de
+// DIGEST_INTERVAL y envía el resumen a cada destinatario de DIGEST_TO.
+func runDigest() error {
+	recipients := digestRecipients()
+	if len(recipients) == 0 {
+		log.Println("DIGEST_TO no configurado, omitiendo el digest")
+		return nil
+	}
+
+	now := time.Now()
+	from := now.Add(-digestInterval())
+
+	analytics, err := getStore().Query(from, now)
+	if err != nil {
+		return fmt.Errorf("error al calcular analytics del digest: %v", err)
+	}
+
+	data := digestData{From: from, To: now, Analytics: analytics}
+
+	var textBody, htmlBody bytes.Buffer
+	if err := digestTextTemplate.Execute(&textBody, data); err != nil {
+		return fmt.Errorf("error al renderizar el digest en texto: %v", err)
+	}
+	if err := digestHTMLTemplate.Execute(&htmlBody, data); err != nil {
+		return fmt.Errorf("error al renderizar el digest en HTML: %v", err)
+	}
+
+	config, err := newSmtpConfig()
+	if err != nil {
+		return fmt.Errorf("error de configuración SMTP para el digest: %v", err)
+	}
+
+	var lastErr error
+	for _, recipient := range recipients {
+		config.ToEmail = recipient
+		if err := sendDigestEmail(config, textBody.String(), htmlBody.String()); err != nil {
+			log.Printf("Error al enviar el digest a %s: %v", recipient, err)
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// sendDigestEmail envía el digest como multipart/alternative (texto + HTML)
+// con la misma SmtpConfig que sendEmail, pero directamente vía
+// smtp.SendMail y no a través de internal/mailer: no firma con DKIM ni
+// aplica SMTP_TLS_MODE.
+func sendDigestEmail(config SmtpConfig, textBody, htmlBody string) error {
+	const boundary = "softexlabs-digest-boundary"
+
+	msg := "From: " + config.User + "\r\n" +
+		"To: " + config.ToEmail + "\r\n" +
+		"Subject: Resumen de actividad de contacto - Softex Labs\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/alternative; boundary=" + boundary + "\r\n\r\n" +
+		"--" + boundary + "\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n" +
+		textBody + "\r\n\r\n" +
+		"--" + boundary + "\r\n" +
+		"Content-Type: text/html; charset=UTF-8\r\n\r\n" +
+		htmlBody + "\r\n\r\n" +
+		"--" + boundary + "--"
+
+	auth := smtp.PlainAuth("", config.User, config.Pass, config.Host)
+	addr := config.Host + ":" + config.Port
+
+	return smtp.SendMail(addr, auth, config.User, []string{config.ToEmail}, []byte(msg))
+}
+
+// DigestRunHandler dispara el digest inmediatamente, para poder probarlo sin
+// esperar al siguiente tick del scheduler. Usa la misma autenticación
+// X-Admin-Key que AnalyticsHandler y EmailTestHandler.
+func DigestRunHandler(w http.ResponseWriter, r *http.Request) {
+	adminKey := os.Getenv("ADMIN_KEY")
+	if adminKey == "" || r.Header.Get("X-Admin-Key") != adminKey {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := runDigest(); err != nil {
+		sendJSONError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONSuccess(w, "Digest ejecutado correctamente")
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDigestTemplatesRenderAnalytics(t *testing.T) {
+	data := digestData{
+		From: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Analytics: Analytics{
+			TotalSubmissions: 5,
+			SuccessRate:      80,
+			TopCountries:     map[string]int{"Local": 5},
+			HourlyStats:      map[string]int{"14": 5},
+			ErrorStats:       map[string]int{"HTTP 500": 1},
+		},
+	}
+
+	var textBody strings.Builder
+	if err := digestTextTemplate.Execute(&textBody, data); err != nil {
+		t.Fatalf("digestTextTemplate.Execute() error = %v", err)
+	}
+	if !strings.Contains(textBody.String(), "Total de envíos: 5") {
+		t.Errorf("el digest en texto no incluye el total: %s", textBody.String())
+	}
+
+	var htmlBody strings.Builder
+	if err := digestHTMLTemplate.Execute(&htmlBody, data); err != nil {
+		t.Fatalf("digestHTMLTemplate.Execute() error = %v", err)
+	}
+	if !strings.Contains(htmlBody.String(), "Local: 5") {
+		t.Errorf("el digest en HTML no incluye el país: %s", htmlBody.String())
+	}
+}
+
+func TestRunDigestWithoutRecipientsIsNoop(t *testing.T) {
+	t.Setenv("DIGEST_TO", "")
+
+	if err := runDigest(); err != nil {
+		t.Errorf("runDigest() sin DIGEST_TO no debería fallar, error = %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"log"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// GeoResolver resuelve el país de una IP. Se expone como interfaz para poder
+// inyectar un resolver falso en los tests sin depender de un archivo .mmdb.
+type GeoResolver interface {
+	Country(ip net.IP) string
+}
+
+// MaxMindResolver resuelve países usando una base de datos GeoLite2-Country.
+type MaxMindResolver struct {
+	db *maxminddb.Reader
+}
+
+// NewMaxMindResolver abre el archivo .mmdb en path.
+func NewMaxMindResolver(path string) (*MaxMindResolver, error) {
+	db, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &MaxMindResolver{db: db}, nil
+}
+
+// Country implementa GeoResolver.
+func (r *MaxMindResolver) Country(ip net.IP) string {
+	var record struct {
+		Country struct {
+			Names map[string]string `maxminddb:"names"`
+		} `maxminddb:"country"`
+	}
+
+	if err := r.db.Lookup(ip, &record); err != nil {
+		return "Unknown"
+	}
+
+	name := record.Country.Names["en"]
+	if name == "" {
+		return "Unknown"
+	}
+	return name
+}
+
+// Close libera el archivo .mmdb.
+func (r *MaxMindResolver) Close() error {
+	return r.db.Close()
+}
+
+// nullGeoResolver se usa cuando no hay GEOIP_DB configurado o no pudo
+// cargarse, para que el resto del código no tenga que manejar el caso nil.
+type nullGeoResolver struct{}
+
+func (nullGeoResolver) Country(net.IP) string {
+	return "Unknown"
+}
+
+var (
+	geoOnce     sync.Once
+	geoResolver GeoResolver
+)
+
+// getGeoResolver carga perezosamente el resolver configurado por GEOIP_DB.
+func getGeoResolver() GeoResolver {
+	geoOnce.Do(func() {
+		path := os.Getenv("GEOIP_DB")
+		if path == "" {
+			geoResolver = nullGeoResolver{}
+			return
+		}
+
+		resolver, err := NewMaxMindResolver(path)
+		if err != nil {
+			log.Printf("Error al cargar GEOIP_DB (%s), se usará 'Unknown': %v", path, err)
+			geoResolver = nullGeoResolver{}
+			return
+		}
+		geoResolver = resolver
+	})
+	return geoResolver
+}
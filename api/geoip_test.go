@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"testing"
+)
+
+func TestGetCountryFromIPLocal(t *testing.T) {
+	if got := getCountryFromIP("127.0.0.1"); got != "Local" {
+		t.Errorf("getCountryFromIP(127.0.0.1) = %q, se esperaba Local", got)
+	}
+	if got := getCountryFromIP("::1"); got != "Local" {
+		t.Errorf("getCountryFromIP(::1) = %q, se esperaba Local", got)
+	}
+}
+
+func TestGetCountryFromIPInvalid(t *testing.T) {
+	if got := getCountryFromIP("not-an-ip"); got != "Unknown" {
+		t.Errorf("getCountryFromIP(not-an-ip) = %q, se esperaba Unknown", got)
+	}
+}
+
+func TestIsTrustedProxy(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8,192.168.1.1/32")
+
+	if !isTrustedProxy("10.1.2.3") {
+		t.Error("se esperaba que 10.1.2.3 fuera un proxy confiable")
+	}
+	if !isTrustedProxy("192.168.1.1") {
+		t.Error("se esperaba que 192.168.1.1 fuera un proxy confiable")
+	}
+	if isTrustedProxy("8.8.8.8") {
+		t.Error("8.8.8.8 no debería ser un proxy confiable")
+	}
+}
+
+func TestIsTrustedProxyUnset(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "")
+	if isTrustedProxy("10.0.0.1") {
+		t.Error("sin TRUSTED_PROXIES no se debería confiar en ninguna IP")
+	}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/jhonatangrajales/softex-lab/internal/logx"
+)
+
+var (
+	loggerOnce sync.Once
+	logger     *logx.Logger
+)
+
+// getLogger devuelve el logger estructurado compartido, inicializándolo
+// desde el entorno (LOG_LEVEL, LOG_PII) y enganchando la recarga en
+// caliente por SIGHUP la primera vez que se necesita.
+func getLogger() *logx.Logger {
+	loggerOnce.Do(func() {
+		logger = logx.NewFromEnv()
+		logger.WatchSIGHUP()
+	})
+	return logger
+}
+
+// requestCorrelationID devuelve el X-Request-ID de la solicitud, o genera
+// uno nuevo si no viene.
+func requestCorrelationID(r *http.Request) string {
+	if id := r.Header.Get("X-Request-ID"); id != "" {
+		return id
+	}
+	return logx.NewCorrelationID()
+}
+
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+type logLevelResponse struct {
+	Level string `json:"level"`
+	PII   bool   `json:"pii"`
+}
+
+// LogLevelHandler expone el nivel de log actual (GET) y permite cambiarlo
+// en caliente (POST {"level": "debug"}), guardado por la misma
+// X-Admin-Key que el resto de los endpoints administrativos.
+func LogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	adminKey := os.Getenv("ADMIN_KEY")
+	if adminKey == "" || r.Header.Get("X-Admin-Key") != adminKey {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	l := getLogger()
+
+	if r.Method == http.MethodPost {
+		var req logLevelRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			sendJSONError(w, "cuerpo inválido", http.StatusBadRequest)
+			return
+		}
+		level, err := logx.ParseLevel(req.Level)
+		if err != nil {
+			sendJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		l.SetLevel(level)
+	} else if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(logLevelResponse{Level: l.Level().String(), PII: l.PII()})
+}
@@ -1,13 +1,16 @@
-package api
+package handler
 
 import (
-	"encoding/json"
+	"context"
 	"log"
 	"net/http"
 	"net/smtp"
 	"os"
-	"strings"
+	"strconv"
 	"time"
+
+	"github.com/jhonatangrajales/softex-lab/internal/logx"
+	"github.com/jhonatangrajales/softex-lab/notifier"
 )
 
 // NotificationConfig representa la configuración de notificaciones
@@ -18,75 +21,33 @@ type NotificationConfig struct {
 	SlackEnabled bool   `json:"slack_enabled"`
 }
 
-// SlackMessage representa un mensaje de Slack
-type SlackMessage struct {
-	Channel     string            `json:"channel"`
-	Username    string            `json:"username"`
-	IconEmoji   string            `json:"icon_emoji"`
-	Attachments []SlackAttachment `json:"attachments"`
-}
-
-// SlackAttachment representa un attachment de Slack
-type SlackAttachment struct {
-	Color     string       `json:"color"`
-	Title     string       `json:"title"`
-	Text      string       `json:"text"`
-	Fields    []SlackField `json:"fields"`
-	Timestamp int64        `json:"ts"`
-}
-
-// SlackField representa un campo de Slack
-type SlackField struct {
-	Title string `json:"title"`
-	Value string `json:"value"`
-	Short bool   `json:"short"`
-}
-
-// Enviar notificación a Slack
-func sendSlackNotification(data ContactData, clientIP string) error {
-	webhookURL := os.Getenv("SLACK_WEBHOOK_URL")
-	if webhookURL == "" {
-		return nil // No configurado, no es error
-	}
-
-	message := SlackMessage{
-		Channel:   "#contacto",
-		Username:  "Softex Labs Bot",
-		IconEmoji: ":email:",
-		Attachments: []SlackAttachment{
-			{
-				Color: "good",
-				Title: "Nuevo mensaje de contacto",
-				Text:  "Se ha recibido un nuevo mensaje desde el sitio web",
-				Fields: []SlackField{
-					{Title: "Nombre", Value: data.Name, Short: true},
-					{Title: "Email", Value: data.Email, Short: true},
-					{Title: "IP", Value: clientIP, Short: true},
-					{Title: "Fecha", Value: time.Now().Format("2006-01-02 15:04:05"), Short: true},
-					{Title: "Mensaje", Value: data.Message, Short: false},
-				},
-				Timestamp: time.Now().Unix(),
-			},
-		},
-	}
-
-	jsonData, err := json.Marshal(message)
+// sendNotifications despacha data a todos los backends habilitados del
+// paquete notifier (Slack, Discord, Telegram, Matrix, webhook genérico). Los
+// errores de cada backend solo se registran: un canal caído no debe impedir
+// que el formulario responda con éxito al usuario.
+func sendNotifications(data ContactData, clientIP string) {
+	cfg, err := notifier.LoadConfig()
 	if err != nil {
-		return err
+		log.Printf("Error al cargar la configuración de notificaciones: %v", err)
+		return
 	}
 
-	resp, err := http.Post(webhookURL, "application/json", strings.NewReader(string(jsonData)))
+	notifiers, err := cfg.Build()
 	if err != nil {
-		return err
+		log.Printf("Error al construir los notificadores: %v", err)
+		return
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return err
+	if len(notifiers) == 0 {
+		return
 	}
 
-	log.Println("Notificación de Slack enviada exitosamente")
-	return nil
+	contactData := notifier.ContactData{Name: data.Name, Email: data.Email, Message: data.Message}
+	errs := notifier.Dispatch(context.Background(), notifiers, contactData, clientIP, notifier.DefaultTimeout)
+	for i, err := range errs {
+		if err != nil {
+			log.Printf("Error al notificar vía backend %d: %v", i, err)
+		}
+	}
 }
 
 // Enviar auto-respuesta al usuario
@@ -141,8 +102,9 @@ func sendAutoResponse(config SmtpConfig, data ContactData) error {
 func HandlerWithNotifications(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 	clientIP := getClientIP(r)
+	ctx := logx.WithCorrelationID(r.Context(), requestCorrelationID(r))
 
-	log.Printf("Solicitud recibida - Método: %s, IP: %s", r.Method, clientIP)
+	getLogger().Info(ctx, "solicitud recibida", logx.Fields{"method": r.Method, "client_ip": clientIP})
 
 	// Configuración de CORS
 	allowedOrigin := os.Getenv("ALLOWED_ORIGIN")
@@ -169,8 +131,11 @@ func HandlerWithNotifications(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Rate limiting
-	if err := checkRateLimit(clientIP); err != nil {
-		log.Printf("Rate limit excedido para IP %s: %v", clientIP, err)
+	rateLimit, err := checkRateLimit(clientIP)
+	w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(rateLimit.Remaining, 10))
+	if err != nil {
+		getLogger().Warn(ctx, "rate limit excedido", logx.Fields{"client_ip": clientIP, "status": http.StatusTooManyRequests})
+		w.Header().Set("Retry-After", strconv.Itoa(int(rateLimit.RetryAfter.Round(time.Second).Seconds())))
 		sendJSONError(w, err.Error(), http.StatusTooManyRequests)
 		return
 	}
@@ -181,17 +146,18 @@ func HandlerWithNotifications(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parsear y validar
-	data, err := parseAndValidateRequest(r)
+	data, err := parseAndValidateRequest(r, clientIP)
 	if err != nil {
-		log.Printf("Error de validación para IP %s: %v", clientIP, err)
+		getLogger().Info(ctx, "error de validación", logx.Fields{"client_ip": clientIP, "status": http.StatusBadRequest, "error": err.Error()})
 		sendJSONError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	getLogger().DebugPII(ctx, "datos de contacto recibidos", logx.Fields{"name": data.Name, "email": data.Email, "message": data.Message})
 
 	// Configuración SMTP
 	config, err := newSmtpConfig()
 	if err != nil {
-		log.Printf("Error de configuración SMTP: %v", err)
+		getLogger().Error(ctx, "error de configuración SMTP", logx.Fields{"status": http.StatusInternalServerError})
 		sendJSONError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -199,16 +165,14 @@ func HandlerWithNotifications(w http.ResponseWriter, r *http.Request) {
 	// Enviar email principal
 	err = sendEmail(config, data, clientIP)
 	if err != nil {
-		log.Printf("Error al enviar correo para IP %s: %v", clientIP, err)
+		getLogger().Error(ctx, "error al enviar correo", logx.Fields{"client_ip": clientIP, "error": err.Error()})
 		sendJSONError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	// Enviar notificaciones adicionales (no bloquear si fallan)
 	go func() {
-		if err := sendSlackNotification(data, clientIP); err != nil {
-			log.Printf("Error enviando notificación Slack: %v", err)
-		}
+		sendNotifications(data, clientIP)
 
 		if err := sendAutoResponse(config, data); err != nil {
 			log.Printf("Error enviando auto-respuesta: %v", err)
@@ -216,7 +180,11 @@ func HandlerWithNotifications(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	duration := time.Since(startTime)
-	log.Printf("Correo enviado exitosamente - IP: %s, Duración: %v", clientIP, duration)
+	getLogger().Info(ctx, "correo enviado exitosamente", logx.Fields{
+		"client_ip":   clientIP,
+		"status":      http.StatusOK,
+		"duration_ms": duration.Milliseconds(),
+	})
 
 	sendJSONSuccess(w, "¡Mensaje enviado con éxito! Te responderemos pronto.")
 }
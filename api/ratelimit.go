@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimitResult es el resultado de consultar un RateLimiter para una key.
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int64
+	RetryAfter time.Duration
+}
+
+// RateLimiter implementa un token bucket: cada key tiene un cupo de
+// capacity tokens que se rellena a razón de refillPerSec tokens/seg, y cada
+// llamada a Allow intenta consumir uno.
+type RateLimiter interface {
+	Allow(key string) (RateLimitResult, error)
+}
+
+const (
+	defaultRateLimitCapacity     = 3
+	defaultRateLimitRefillPerMin = 0.6 // 3 tokens cada 5 minutos, como el límite original
+)
+
+// rateLimitCapacityAndRefill lee RATE_LIMIT_CAPACITY y
+// RATE_LIMIT_REFILL_PER_MIN, con los valores por defecto del límite
+// original si no están definidas.
+func rateLimitCapacityAndRefill() (capacity int64, refillPerSec float64) {
+	capacity = defaultRateLimitCapacity
+	if v := os.Getenv("RATE_LIMIT_CAPACITY"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil && parsed > 0 {
+			capacity = parsed
+		}
+	}
+
+	refillPerMin := float64(defaultRateLimitRefillPerMin)
+	if v := os.Getenv("RATE_LIMIT_REFILL_PER_MIN"); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed > 0 {
+			refillPerMin = parsed
+		}
+	}
+
+	return capacity, refillPerMin / 60
+}
+
+var (
+	rateLimiterOnce sync.Once
+	rateLimiter     RateLimiter
+)
+
+// getRateLimiter construye el RateLimiter configurado por RATE_LIMIT_BACKEND
+// ("memory" por defecto, o "redis") la primera vez que se necesita.
+func getRateLimiter() RateLimiter {
+	rateLimiterOnce.Do(func() {
+		capacity, refillPerSec := rateLimitCapacityAndRefill()
+
+		if os.Getenv("RATE_LIMIT_BACKEND") == "redis" {
+			limiter, err := newRedisRateLimiter(os.Getenv("REDIS_URL"), capacity, refillPerSec)
+			if err != nil {
+				log.Printf("No se pudo inicializar el rate limiter de Redis, usando el de memoria: %v", err)
+			} else {
+				rateLimiter = limiter
+				return
+			}
+		}
+
+		rateLimiter = newMemoryRateLimiter(capacity, refillPerSec)
+	})
+	return rateLimiter
+}
+
+// bucket guarda el estado de un token bucket individual.
+type bucket struct {
+	mu             sync.Mutex
+	tokens         float64
+	lastRefillNano int64
+}
+
+// memoryRateLimiter implementa RateLimiter en proceso, con un bucket por key
+// en un sync.Map y limpieza periódica de buckets inactivos.
+type memoryRateLimiter struct {
+	buckets      sync.Map // string -> *bucket
+	capacity     float64
+	refillPerSec float64
+}
+
+func newMemoryRateLimiter(capacity int64, refillPerSec float64) *memoryRateLimiter {
+	l := &memoryRateLimiter{capacity: float64(capacity), refillPerSec: refillPerSec}
+	go l.cleanupLoop()
+	return l
+}
+
+func (l *memoryRateLimiter) Allow(key string) (RateLimitResult, error) {
+	value, _ := l.buckets.LoadOrStore(key, &bucket{tokens: l.capacity, lastRefillNano: time.Now().UnixNano()})
+	b := value.(*bucket)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now().UnixNano()
+	elapsedSec := float64(now-b.lastRefillNano) / float64(time.Second)
+	b.tokens = math.Min(l.capacity, b.tokens+elapsedSec*l.refillPerSec)
+	b.lastRefillNano = now
+
+	if b.tokens < 1 {
+		missing := 1 - b.tokens
+		retryAfter := time.Duration(missing/l.refillPerSec*float64(time.Second)) + time.Millisecond
+		return RateLimitResult{Allowed: false, Remaining: 0, RetryAfter: retryAfter}, nil
+	}
+
+	b.tokens--
+	return RateLimitResult{Allowed: true, Remaining: int64(b.tokens)}, nil
+}
+
+// cleanupLoop elimina periódicamente los buckets que ya están llenos y no se
+// han usado en un buen rato, para no acumular memoria con IPs que ya no
+// vuelven a aparecer.
+func (l *memoryRateLimiter) cleanupLoop() {
+	for {
+		time.Sleep(10 * time.Minute)
+		cutoff := time.Now().Add(-10 * time.Minute).UnixNano()
+		l.buckets.Range(func(key, value interface{}) bool {
+			b := value.(*bucket)
+			b.mu.Lock()
+			elapsedSec := float64(time.Now().UnixNano()-b.lastRefillNano) / float64(time.Second)
+			tokens := math.Min(l.capacity, b.tokens+elapsedSec*l.refillPerSec)
+			idle := b.lastRefillNano < cutoff && tokens >= l.capacity
+			b.mu.Unlock()
+			if idle {
+				l.buckets.Delete(key)
+			}
+			return true
+		})
+	}
+}
+
+// tokenBucketScript replica la semántica de CL.THROTTLE sobre Redis: lee el
+// estado actual, aplica el relleno proporcional al tiempo transcurrido,
+// decide si hay token disponible y guarda el nuevo estado, todo en un único
+// EVAL atómico para que las réplicas nunca compitan entre sí.
+const tokenBucketScript = `
+local key = KEYS[1]
+local capacity = tonumber(ARGV[1])
+local refill_per_sec = tonumber(ARGV[2])
+local now_ns = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", key, "tokens", "last")
+local tokens = tonumber(state[1])
+local last = tonumber(state[2])
+if tokens == nil then
+	tokens = capacity
+	last = now_ns
+end
+
+local elapsed_sec = (now_ns - last) / 1e9
+if elapsed_sec < 0 then
+	elapsed_sec = 0
+end
+tokens = math.min(capacity, tokens + elapsed_sec * refill_per_sec)
+
+local allowed = 0
+if tokens >= 1 then
+	allowed = 1
+	tokens = tokens - 1
+end
+
+redis.call("HMSET", key, "tokens", tokens, "last", now_ns)
+redis.call("EXPIRE", key, math.ceil(capacity / refill_per_sec) + 60)
+
+return {allowed, tokens}
+`
+
+// redisRateLimiter implementa RateLimiter sobre Redis, para que el cupo de
+// cada key se comparta entre todas las réplicas del servicio.
+type redisRateLimiter struct {
+	client       *redis.Client
+	script       *redis.Script
+	capacity     int64
+	refillPerSec float64
+}
+
+func newRedisRateLimiter(url string, capacity int64, refillPerSec float64) (*redisRateLimiter, error) {
+	if url == "" {
+		return nil, fmt.Errorf("REDIS_URL no está definida")
+	}
+
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("error al parsear REDIS_URL: %v", err)
+	}
+
+	return &redisRateLimiter{
+		client:       redis.NewClient(opts),
+		script:       redis.NewScript(tokenBucketScript),
+		capacity:     capacity,
+		refillPerSec: refillPerSec,
+	}, nil
+}
+
+func (l *redisRateLimiter) Allow(key string) (RateLimitResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	result, err := l.script.Run(ctx, l.client, []string{"ratelimit:" + key},
+		l.capacity, l.refillPerSec, time.Now().UnixNano()).Result()
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("error al ejecutar el script de rate limit en Redis: %v", err)
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		return RateLimitResult{}, fmt.Errorf("respuesta inesperada del script de rate limit")
+	}
+
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(int64)
+
+	res := RateLimitResult{Allowed: allowed == 1, Remaining: remaining}
+	if !res.Allowed {
+		missing := 1 - float64(remaining)
+		res.RetryAfter = time.Duration(missing/l.refillPerSec*float64(time.Second)) + time.Millisecond
+	}
+	return res, nil
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimiterEnforcesCapacity(t *testing.T) {
+	limiter := newMemoryRateLimiter(2, 1.0/60) // 2 tokens, 1 por minuto
+
+	for i := 0; i < 2; i++ {
+		result, err := limiter.Allow("1.2.3.4")
+		if err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+		if !result.Allowed {
+			t.Errorf("solicitud %d debería haber sido permitida", i+1)
+		}
+	}
+
+	result, err := limiter.Allow("1.2.3.4")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if result.Allowed {
+		t.Error("la tercera solicitud no debería haber sido permitida")
+	}
+	if result.RetryAfter <= 0 {
+		t.Error("se esperaba un RetryAfter positivo")
+	}
+}
+
+func TestMemoryRateLimiterRefillsOverTime(t *testing.T) {
+	limiter := newMemoryRateLimiter(1, 1000) // relleno muy rápido para no depender de tiempos largos
+
+	result, err := limiter.Allow("5.6.7.8")
+	if err != nil || !result.Allowed {
+		t.Fatalf("se esperaba que la primera solicitud pasara, result = %+v, err = %v", result, err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	result, err = limiter.Allow("5.6.7.8")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if !result.Allowed {
+		t.Error("se esperaba que el bucket se hubiera rellenado para permitir otra solicitud")
+	}
+}
+
+func TestMemoryRateLimiterTracksKeysIndependently(t *testing.T) {
+	limiter := newMemoryRateLimiter(1, 1.0/60)
+
+	if result, err := limiter.Allow("10.0.0.1"); err != nil || !result.Allowed {
+		t.Fatalf("se esperaba que la IP 10.0.0.1 pasara, result = %+v, err = %v", result, err)
+	}
+	if result, err := limiter.Allow("10.0.0.2"); err != nil || !result.Allowed {
+		t.Fatalf("se esperaba que la IP 10.0.0.2 pasara de forma independiente, result = %+v, err = %v", result, err)
+	}
+}
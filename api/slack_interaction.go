@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+)
+
+// slackDialogState es el estado que adjuntamos a los diálogos de Slack al
+// abrirlos (como el campo "state"), para saber a quién responder cuando
+// soporte envía la respuesta: Slack no asocia un dialog_submission con el
+// contacto original de ninguna otra forma.
+type slackDialogState struct {
+	To   string `json:"to"`
+	Name string `json:"name"`
+}
+
+// slackInteractionPayload cubre los campos que usamos de los callbacks
+// interactivos de Slack (dialog_submission, block_actions); el resto del
+// payload se ignora.
+type slackInteractionPayload struct {
+	Type       string            `json:"type"`
+	Submission map[string]string `json:"submission"`
+	State      string            `json:"state"`
+}
+
+// SlackInteraction recibe los callbacks interactivos de Slack (configurados
+// como Request URL de la app) que permiten a soporte responder un mensaje
+// de contacto directamente desde Slack. Verifica X-Slack-Signature antes de
+// procesar nada; solo una respuesta firmada dispara el envío de correo.
+func SlackInteraction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "No se pudo leer el cuerpo de la solicitud", http.StatusBadRequest)
+		return
+	}
+
+	if err := verifySlackSignature(os.Getenv("SLACK_SIGNING_SECRET"), r.Header.Get("X-Slack-Request-Timestamp"), r.Header.Get("X-Slack-Signature"), body); err != nil {
+		log.Printf("Firma de Slack inválida: %v", err)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "No se pudo parsear el formulario", http.StatusBadRequest)
+		return
+	}
+
+	var payload slackInteractionPayload
+	if err := json.Unmarshal([]byte(r.PostFormValue("payload")), &payload); err != nil {
+		http.Error(w, "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	if payload.Type != "dialog_submission" {
+		// Otros tipos de interacción (p.ej. block_actions) solo se reconocen.
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var state slackDialogState
+	if err := json.Unmarshal([]byte(payload.State), &state); err != nil || state.To == "" {
+		log.Printf("Estado de diálogo de Slack inválido: %v", err)
+		http.Error(w, "Estado de diálogo inválido", http.StatusBadRequest)
+		return
+	}
+
+	reply := payload.Submission["reply"]
+	if reply == "" {
+		http.Error(w, "La respuesta no puede estar vacía", http.StatusBadRequest)
+		return
+	}
+
+	config, err := newSmtpConfig()
+	if err != nil {
+		log.Printf("Error de configuración SMTP: %v", err)
+		http.Error(w, "Error de configuración del servidor", http.StatusInternalServerError)
+		return
+	}
+	config.ToEmail = state.To
+
+	replyData := ContactData{
+		Name:    state.Name,
+		Email:   config.User,
+		Message: reply,
+	}
+
+	if err := sendEmail(config, replyData, "slack-interaction"); err != nil {
+		log.Printf("Error al enviar la respuesta desde Slack: %v", err)
+		http.Error(w, "No se pudo enviar la respuesta", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// slackSignatureReplayWindow es la antigüedad máxima aceptada para
+// X-Slack-Request-Timestamp antes de rechazar la solicitud como un posible
+// ataque de repetición.
+const slackSignatureReplayWindow = 5 * time.Minute
+
+// verifySlackSignature valida X-Slack-Signature según el esquema v0 de
+// Slack: el basestring es "v0:{timestamp}:{rawBody}", firmado con
+// HMAC-SHA256 usando signingSecret, codificado en hex y prefijado con "v0=".
+func verifySlackSignature(signingSecret, timestamp, signature string, body []byte) error {
+	if signingSecret == "" {
+		return fmt.Errorf("SLACK_SIGNING_SECRET no está configurada")
+	}
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("faltan las cabeceras de firma de Slack")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("timestamp inválido: %v", err)
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > slackSignatureReplayWindow || age < -slackSignatureReplayWindow {
+		return fmt.Errorf("timestamp fuera de la ventana permitida de %v", slackSignatureReplayWindow)
+	}
+
+	baseString := fmt.Sprintf("v0:%s:%s", timestamp, body)
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte(baseString))
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("firma de Slack inválida")
+	}
+
+	return nil
+}
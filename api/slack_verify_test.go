@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func sign(secret, timestamp string, body []byte) string {
+	baseString := fmt.Sprintf("v0:%s:%s", timestamp, body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(baseString))
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySlackSignatureValid(t *testing.T) {
+	secret := "test-secret"
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	body := []byte(`payload=%7B%22type%22%3A%22dialog_submission%22%7D`)
+	signature := sign(secret, timestamp, body)
+
+	if err := verifySlackSignature(secret, timestamp, signature, body); err != nil {
+		t.Errorf("verifySlackSignature() error = %v, se esperaba nil", err)
+	}
+}
+
+func TestVerifySlackSignatureWrongSecret(t *testing.T) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	body := []byte(`payload=%7B%7D`)
+	signature := sign("otro-secreto", timestamp, body)
+
+	if err := verifySlackSignature("test-secret", timestamp, signature, body); err == nil {
+		t.Error("se esperaba un error por firma inválida")
+	}
+}
+
+func TestVerifySlackSignatureExpiredTimestamp(t *testing.T) {
+	secret := "test-secret"
+	timestamp := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	body := []byte(`payload=%7B%7D`)
+	signature := sign(secret, timestamp, body)
+
+	if err := verifySlackSignature(secret, timestamp, signature, body); err == nil {
+		t.Error("se esperaba un error por timestamp expirado")
+	}
+}
+
+func TestVerifySlackSignatureMissingSecret(t *testing.T) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	if err := verifySlackSignature("", timestamp, "v0=abc", []byte("body")); err == nil {
+		t.Error("se esperaba un error cuando SLACK_SIGNING_SECRET no está configurada")
+	}
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SubmissionStore persiste las submisiones del formulario de contacto y
+// permite consultarlas de forma agregada para analytics, sin depender de un
+// slice en memoria que se pierde en cada arranque en frío.
+type SubmissionStore interface {
+	Record(sub FormSubmission) error
+	Query(from, to time.Time, filters ...QueryFilter) (Analytics, error)
+	Close() error
+}
+
+type queryOptions struct {
+	country string
+}
+
+// QueryFilter restringe los resultados de Query.
+type QueryFilter func(*queryOptions)
+
+// WithCountry limita Query a un país concreto.
+func WithCountry(country string) QueryFilter {
+	return func(o *queryOptions) { o.country = country }
+}
+
+// SQLiteStore implementa SubmissionStore sobre modernc.org/sqlite, que no
+// requiere CGO y por tanto funciona en builds de despliegue estándar.
+type SQLiteStore struct {
+	db            *sql.DB
+	retentionDays int
+}
+
+// NewSQLiteStore abre (o crea) la base de datos en path, aplica las
+// migraciones necesarias y arranca la limpieza periódica de filas fuera de
+// la ventana de retención, igual que cleanupVisitors hace con el rate
+// limiter en main.go.
+func NewSQLiteStore(path string, retentionDays int) (*SQLiteStore, error) {
+	// _time_format=sqlite guarda los time.Time en un formato que strftime
+	// entiende; el formato por defecto del driver (time.Time.String) no lo
+	// es y la agregación por hora devolvería NULL.
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("sqlite", path+sep+"_time_format=sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("error al abrir la base de datos: %v", err)
+	}
+
+	store := &SQLiteStore{db: db, retentionDays: retentionDays}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	go store.cleanupOldSubmissions()
+
+	return store, nil
+}
+
+func (s *SQLiteStore) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS submissions (
+			id      INTEGER PRIMARY KEY AUTOINCREMENT,
+			ts      DATETIME NOT NULL,
+			success INTEGER NOT NULL,
+			country TEXT NOT NULL,
+			error   TEXT NOT NULL DEFAULT ''
+		);
+		CREATE INDEX IF NOT EXISTS idx_submissions_ts ON submissions(ts);
+	`)
+	if err != nil {
+		return fmt.Errorf("error al migrar la base de datos: %v", err)
+	}
+	return nil
+}
+
+// Record guarda una submisión del formulario.
+func (s *SQLiteStore) Record(sub FormSubmission) error {
+	_, err := s.db.Exec(
+		`INSERT INTO submissions (ts, success, country, error) VALUES (?, ?, ?, ?)`,
+		sub.Timestamp, sub.Success, sub.Country, sub.Error,
+	)
+	if err != nil {
+		return fmt.Errorf("error al registrar la submisión: %v", err)
+	}
+	return nil
+}
+
+// Query calcula Analytics para el rango [from, to) usando agregados SQL en
+// lugar de recorrer todas las submisiones en memoria.
+func (s *SQLiteStore) Query(from, to time.Time, filters ...QueryFilter) (Analytics, error) {
+	opts := queryOptions{}
+	for _, f := range filters {
+		f(&opts)
+	}
+
+	where := "ts >= ? AND ts < ?"
+	args := []interface{}{from, to}
+	if opts.country != "" {
+		where += " AND country = ?"
+		args = append(args, opts.country)
+	}
+
+	analytics := Analytics{
+		TopCountries: make(map[string]int),
+		HourlyStats:  make(map[string]int),
+		ErrorStats:   make(map[string]int),
+	}
+
+	row := s.db.QueryRow(fmt.Sprintf(
+		`SELECT COUNT(*), COALESCE(SUM(success), 0) FROM submissions WHERE %s`, where), args...)
+
+	var total, successCount int64
+	if err := row.Scan(&total, &successCount); err != nil {
+		return analytics, fmt.Errorf("error al calcular totales: %v", err)
+	}
+
+	analytics.TotalSubmissions = total
+	if total > 0 {
+		analytics.SuccessRate = float64(successCount) / float64(total) * 100
+	}
+	analytics.LastUpdated = time.Now()
+
+	countryRows, err := s.db.Query(fmt.Sprintf(
+		`SELECT country, COUNT(*) FROM submissions WHERE %s GROUP BY country`, where), args...)
+	if err != nil {
+		return analytics, fmt.Errorf("error al agrupar por país: %v", err)
+	}
+	defer countryRows.Close()
+	for countryRows.Next() {
+		var country string
+		var count int
+		if err := countryRows.Scan(&country, &count); err != nil {
+			return analytics, err
+		}
+		analytics.TopCountries[country] = count
+	}
+
+	hourRows, err := s.db.Query(fmt.Sprintf(
+		`SELECT strftime('%%H', ts), COUNT(*) FROM submissions WHERE %s GROUP BY strftime('%%H', ts)`, where), args...)
+	if err != nil {
+		return analytics, fmt.Errorf("error al agrupar por hora: %v", err)
+	}
+	defer hourRows.Close()
+	for hourRows.Next() {
+		var hour string
+		var count int
+		if err := hourRows.Scan(&hour, &count); err != nil {
+			return analytics, err
+		}
+		analytics.HourlyStats[hour] = count
+	}
+
+	errRows, err := s.db.Query(fmt.Sprintf(
+		`SELECT error, COUNT(*) FROM submissions WHERE %s AND success = 0 AND error != '' GROUP BY error`, where), args...)
+	if err != nil {
+		return analytics, fmt.Errorf("error al agrupar errores: %v", err)
+	}
+	defer errRows.Close()
+	for errRows.Next() {
+		var errMsg string
+		var count int
+		if err := errRows.Scan(&errMsg, &count); err != nil {
+			return analytics, err
+		}
+		analytics.ErrorStats[errMsg] = count
+	}
+
+	return analytics, nil
+}
+
+// cleanupOldSubmissions borra periódicamente las submisiones fuera de la
+// ventana de retención configurada.
+func (s *SQLiteStore) cleanupOldSubmissions() {
+	if s.retentionDays <= 0 {
+		return
+	}
+	for {
+		time.Sleep(1 * time.Hour)
+		cutoff := time.Now().AddDate(0, 0, -s.retentionDays)
+		if _, err := s.db.Exec(`DELETE FROM submissions WHERE ts < ?`, cutoff); err != nil {
+			log.Printf("Error al limpiar submisiones antiguas: %v", err)
+		}
+	}
+}
+
+// Close cierra la conexión a la base de datos.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
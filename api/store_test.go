@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(":memory:", 0)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSQLiteStoreRecordAndQuery(t *testing.T) {
+	store := newTestStore(t)
+
+	now := time.Now()
+	submissions := []FormSubmission{
+		{Timestamp: now, Success: true, Country: "Local"},
+		{Timestamp: now, Success: true, Country: "Unknown"},
+		{Timestamp: now, Success: false, Country: "Unknown", Error: "HTTP 500"},
+	}
+
+	for _, sub := range submissions {
+		if err := store.Record(sub); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	analytics, err := store.Query(now.Add(-time.Minute), now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	if analytics.TotalSubmissions != 3 {
+		t.Errorf("TotalSubmissions = %d, se esperaba 3", analytics.TotalSubmissions)
+	}
+	if got, want := analytics.SuccessRate, float64(2)/3*100; got != want {
+		t.Errorf("SuccessRate = %v, se esperaba %v", got, want)
+	}
+	if analytics.TopCountries["Unknown"] != 2 {
+		t.Errorf("TopCountries[Unknown] = %d, se esperaba 2", analytics.TopCountries["Unknown"])
+	}
+	if analytics.ErrorStats["HTTP 500"] != 1 {
+		t.Errorf("ErrorStats[HTTP 500] = %d, se esperaba 1", analytics.ErrorStats["HTTP 500"])
+	}
+}
+
+func TestSQLiteStoreQueryExcludesOutOfRange(t *testing.T) {
+	store := newTestStore(t)
+
+	old := time.Now().AddDate(0, 0, -10)
+	if err := store.Record(FormSubmission{Timestamp: old, Success: true, Country: "Local"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	analytics, err := store.Query(time.Now().AddDate(0, 0, -1), time.Now())
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if analytics.TotalSubmissions != 0 {
+		t.Errorf("TotalSubmissions = %d, se esperaba 0 para submisiones fuera de rango", analytics.TotalSubmissions)
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jhonatangrajales/softex-lab/internal/logx"
+	"github.com/jhonatangrajales/softex-lab/store"
+)
+
+var (
+	submissionStoreOnce sync.Once
+	submissionStore     store.SubmissionStore
+)
+
+// getSubmissionStore abre perezosamente el SubmissionStore usado para
+// persistir las submisiones del formulario de contacto (distinto del
+// SubmissionStore de analytics en store.go, que solo agrega métricas).
+func getSubmissionStore() store.SubmissionStore {
+	submissionStoreOnce.Do(func() {
+		s, err := store.FromEnv()
+		if err != nil {
+			getLogger().Error(context.Background(), "error al inicializar el store de submissions", logx.Fields{"error": err.Error()})
+			return
+		}
+		submissionStore = s
+	})
+	return submissionStore
+}
+
+// recordContactSubmission persiste data como una submission auditable. Los
+// errores solo se registran: la persistencia no debe impedir que el
+// formulario de contacto responda con éxito al usuario.
+func recordContactSubmission(data ContactData, clientIP, userAgent, origin string) {
+	s := getSubmissionStore()
+	if s == nil {
+		return
+	}
+
+	sub := store.Submission{
+		Name:      data.Name,
+		Email:     data.Email,
+		Message:   data.Message,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Origin:    origin,
+	}
+	if err := s.Save(&sub); err != nil {
+		getLogger().Error(context.Background(), "error al persistir la submission", logx.Fields{"error": err.Error()})
+	}
+}
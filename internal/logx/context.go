@@ -0,0 +1,36 @@
+package logx
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+type correlationIDKey struct{}
+
+// WithCorrelationID adjunta id al contexto para que todas las entradas de
+// log emitidas con ese ctx lo incluyan como correlation_id.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID devuelve el correlation ID adjunto a ctx, o "" si no hay
+// ninguno.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// NewCorrelationID genera un UUID v4 para solicitudes que no llegan con
+// X-Request-ID.
+func NewCorrelationID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// No hay aleatoriedad disponible; preferible un ID degradado a no
+		// poder correlacionar la solicitud en absoluto.
+		return fmt.Sprintf("fallback-%x", b)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,194 @@
+// Package logx implementa un logger estructurado con niveles (TRACE a
+// ERROR), salida JSON y recarga de nivel en caliente vía SIGHUP o un
+// endpoint administrativo, para no tener que reiniciar el servicio solo
+// para subir la verbosidad al investigar un incidente.
+package logx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Level es la severidad de una entrada de log.
+type Level int32
+
+const (
+	TraceLevel Level = iota
+	DebugLevel
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+// String devuelve el nombre del nivel tal como aparece en los logs.
+func (l Level) String() string {
+	switch l {
+	case TraceLevel:
+		return "TRACE"
+	case DebugLevel:
+		return "DEBUG"
+	case InfoLevel:
+		return "INFO"
+	case WarnLevel:
+		return "WARN"
+	case ErrorLevel:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseLevel interpreta el nombre de un nivel (insensible a mayúsculas).
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "TRACE":
+		return TraceLevel, nil
+	case "DEBUG":
+		return DebugLevel, nil
+	case "INFO", "":
+		return InfoLevel, nil
+	case "WARN", "WARNING":
+		return WarnLevel, nil
+	case "ERROR":
+		return ErrorLevel, nil
+	default:
+		return InfoLevel, fmt.Errorf("nivel de log desconocido: %q", s)
+	}
+}
+
+// Fields son los campos adicionales de una entrada de log.
+type Fields map[string]interface{}
+
+// Logger escribe entradas JSON a out, con nivel y gating de PII
+// reconfigurables en caliente de forma segura para concurrencia.
+type Logger struct {
+	out   io.Writer
+	mu    sync.Mutex
+	level int32 // atomic, Level
+	pii   int32 // atomic bool (0/1)
+}
+
+// New crea un Logger que escribe en out con el nivel level. allowPII
+// controla si DebugPII llega a escribirse.
+func New(out io.Writer, level Level, allowPII bool) *Logger {
+	l := &Logger{out: out}
+	l.SetLevel(level)
+	l.SetPII(allowPII)
+	return l
+}
+
+// NewFromEnv crea un Logger leyendo LOG_LEVEL y LOG_PII, escribiendo a
+// os.Stdout.
+func NewFromEnv() *Logger {
+	level, err := ParseLevel(os.Getenv("LOG_LEVEL"))
+	if err != nil {
+		level = InfoLevel
+	}
+	return New(os.Stdout, level, os.Getenv("LOG_PII") == "true")
+}
+
+// ReloadFromEnv vuelve a leer LOG_LEVEL y LOG_PII y actualiza el logger en
+// caliente. Pensado para dispararse desde un manejador de SIGHUP o un
+// endpoint administrativo.
+func (l *Logger) ReloadFromEnv() {
+	if level, err := ParseLevel(os.Getenv("LOG_LEVEL")); err == nil {
+		l.SetLevel(level)
+	}
+	l.SetPII(os.Getenv("LOG_PII") == "true")
+}
+
+// SetLevel cambia el nivel mínimo que se registra.
+func (l *Logger) SetLevel(level Level) {
+	atomic.StoreInt32(&l.level, int32(level))
+}
+
+// Level devuelve el nivel mínimo actual.
+func (l *Logger) Level() Level {
+	return Level(atomic.LoadInt32(&l.level))
+}
+
+// SetPII habilita o deshabilita el registro de campos sensibles vía DebugPII.
+func (l *Logger) SetPII(allow bool) {
+	var v int32
+	if allow {
+		v = 1
+	}
+	atomic.StoreInt32(&l.pii, v)
+}
+
+// PII informa si el registro de campos sensibles está habilitado.
+func (l *Logger) PII() bool {
+	return atomic.LoadInt32(&l.pii) == 1
+}
+
+func (l *Logger) write(ctx context.Context, level Level, msg string, fields Fields) {
+	if level < l.Level() {
+		return
+	}
+
+	entry := make(map[string]interface{}, len(fields)+3)
+	for k, v := range fields {
+		entry[k] = v
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["msg"] = msg
+	if id := CorrelationID(ctx); id != "" {
+		entry["correlation_id"] = id
+	}
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		// No debería pasar con los tipos que usamos como fields, pero no
+		// queremos perder la entrada solo porque un valor no serializa.
+		encoded = []byte(fmt.Sprintf(`{"level":"ERROR","msg":"error al serializar entrada de log: %v"}`, err))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintln(l.out, string(encoded))
+}
+
+// Trace registra una entrada de nivel TRACE.
+func (l *Logger) Trace(ctx context.Context, msg string, fields Fields) {
+	l.write(ctx, TraceLevel, msg, fields)
+}
+
+// Debug registra una entrada de nivel DEBUG. Los campos sensibles (PII) no
+// deben pasarse aquí; usa DebugPII para esos.
+func (l *Logger) Debug(ctx context.Context, msg string, fields Fields) {
+	l.write(ctx, DebugLevel, msg, fields)
+}
+
+// DebugPII registra una entrada de nivel DEBUG que incluye campos
+// sensibles (p.ej. el cuerpo del mensaje de contacto). Solo se escribe si
+// LOG_PII está habilitado; de lo contrario es un no-op, sin importar el
+// nivel configurado.
+func (l *Logger) DebugPII(ctx context.Context, msg string, fields Fields) {
+	if !l.PII() {
+		return
+	}
+	l.write(ctx, DebugLevel, msg, fields)
+}
+
+// Info registra una entrada de nivel INFO.
+func (l *Logger) Info(ctx context.Context, msg string, fields Fields) {
+	l.write(ctx, InfoLevel, msg, fields)
+}
+
+// Warn registra una entrada de nivel WARN.
+func (l *Logger) Warn(ctx context.Context, msg string, fields Fields) {
+	l.write(ctx, WarnLevel, msg, fields)
+}
+
+// Error registra una entrada de nivel ERROR.
+func (l *Logger) Error(ctx context.Context, msg string, fields Fields) {
+	l.write(ctx, ErrorLevel, msg, fields)
+}
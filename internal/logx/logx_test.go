@@ -0,0 +1,109 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Level
+		wantErr bool
+	}{
+		{"debug", DebugLevel, false},
+		{"INFO", InfoLevel, false},
+		{"", InfoLevel, false},
+		{"Warn", WarnLevel, false},
+		{"bogus", InfoLevel, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestLoggerFiltersBelowConfiguredLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, WarnLevel, false)
+
+	logger.Info(context.Background(), "no debería aparecer", nil)
+	if buf.Len() != 0 {
+		t.Fatalf("no se esperaba salida para un nivel por debajo del configurado, se obtuvo: %s", buf.String())
+	}
+
+	logger.Error(context.Background(), "sí debería aparecer", nil)
+	if !strings.Contains(buf.String(), "sí debería aparecer") {
+		t.Errorf("se esperaba la entrada de error en la salida, se obtuvo: %s", buf.String())
+	}
+}
+
+func TestLoggerIncludesCorrelationIDAndFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, InfoLevel, false)
+	ctx := WithCorrelationID(context.Background(), "req-123")
+
+	logger.Info(ctx, "solicitud procesada", Fields{"client_ip": "1.2.3.4", "status": 200})
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("la salida no es JSON válido: %v", err)
+	}
+	if entry["correlation_id"] != "req-123" {
+		t.Errorf("correlation_id = %v, want req-123", entry["correlation_id"])
+	}
+	if entry["client_ip"] != "1.2.3.4" {
+		t.Errorf("client_ip = %v, want 1.2.3.4", entry["client_ip"])
+	}
+}
+
+func TestDebugPIIGatedByToggle(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TraceLevel, false)
+
+	logger.DebugPII(context.Background(), "mensaje sensible", Fields{"message": "hola"})
+	if buf.Len() != 0 {
+		t.Fatalf("no se esperaba salida con LOG_PII deshabilitado, se obtuvo: %s", buf.String())
+	}
+
+	logger.SetPII(true)
+	logger.DebugPII(context.Background(), "mensaje sensible", Fields{"message": "hola"})
+	if !strings.Contains(buf.String(), "mensaje sensible") {
+		t.Errorf("se esperaba la entrada tras habilitar LOG_PII, se obtuvo: %s", buf.String())
+	}
+}
+
+func TestReloadFromEnvUpdatesLevelAndPII(t *testing.T) {
+	logger := New(&bytes.Buffer{}, InfoLevel, false)
+
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("LOG_PII", "true")
+	logger.ReloadFromEnv()
+
+	if logger.Level() != ErrorLevel {
+		t.Errorf("Level() = %v, want ErrorLevel", logger.Level())
+	}
+	if !logger.PII() {
+		t.Error("PII() = false, want true tras recargar LOG_PII=true")
+	}
+}
+
+func TestNewCorrelationIDIsUnique(t *testing.T) {
+	a := NewCorrelationID()
+	b := NewCorrelationID()
+	if a == b {
+		t.Error("se esperaban IDs de correlación distintos")
+	}
+	if a == "" {
+		t.Error("NewCorrelationID() no debería devolver una cadena vacía")
+	}
+}
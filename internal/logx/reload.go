@@ -0,0 +1,21 @@
+package logx
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// WatchSIGHUP arranca una goroutine que recarga LOG_LEVEL y LOG_PII desde
+// el entorno cada vez que el proceso recibe SIGHUP, para poder subir la
+// verbosidad en un incidente sin reiniciar el servicio.
+func (l *Logger) WatchSIGHUP() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+
+	go func() {
+		for range sig {
+			l.ReloadFromEnv()
+		}
+	}()
+}
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"encoding/base64"
+	"io"
+)
+
+// base64LineWriter codifica en base64 y corta la salida en líneas de 76
+// caracteres separadas por CRLF, como exige RFC 2045 para el cuerpo de las
+// partes MIME.
+type base64LineWriter struct {
+	w io.Writer
+}
+
+const base64LineLength = 76
+
+func (lw base64LineWriter) Write(data []byte) (int, error) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	for i := 0; i < len(encoded); i += base64LineLength {
+		end := i + base64LineLength
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		if _, err := lw.w.Write([]byte(encoded[i:end])); err != nil {
+			return 0, err
+		}
+		if _, err := lw.w.Write([]byte("\r\n")); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
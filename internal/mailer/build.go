@@ -0,0 +1,123 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+)
+
+// buildRaw arma el mensaje RFC 822 completo: encabezados de sobre seguidos
+// de un cuerpo multipart/mixed que envuelve un multipart/alternative
+// (text/plain + text/html) y, si los hay, los adjuntos como partes
+// hermanas. El Subject se codifica en RFC 2047 cuando contiene caracteres
+// no-ASCII; From, To, Cc y Reply-To se escriben tal cual, sin codificar.
+func buildRaw(msg Message) ([]byte, error) {
+	text := msg.Text
+	if text == "" {
+		text = stripHTMLTags(msg.HTML)
+	}
+
+	var altBuf bytes.Buffer
+	altWriter := multipart.NewWriter(&altBuf)
+
+	if err := writeTextPart(altWriter, "text/plain", text); err != nil {
+		return nil, fmt.Errorf("error al escribir la parte de texto: %v", err)
+	}
+	if err := writeTextPart(altWriter, "text/html", msg.HTML); err != nil {
+		return nil, fmt.Errorf("error al escribir la parte HTML: %v", err)
+	}
+	if err := altWriter.Close(); err != nil {
+		return nil, fmt.Errorf("error al cerrar multipart/alternative: %v", err)
+	}
+
+	var mixedBuf bytes.Buffer
+	mixedWriter := multipart.NewWriter(&mixedBuf)
+
+	altHeader := textproto.MIMEHeader{}
+	altHeader.Set("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%s", altWriter.Boundary()))
+	altPart, err := mixedWriter.CreatePart(altHeader)
+	if err != nil {
+		return nil, fmt.Errorf("error al crear la parte multipart/alternative: %v", err)
+	}
+	if _, err := altPart.Write(altBuf.Bytes()); err != nil {
+		return nil, fmt.Errorf("error al escribir multipart/alternative: %v", err)
+	}
+
+	for _, att := range msg.Attachments {
+		if err := writeAttachment(mixedWriter, att); err != nil {
+			return nil, fmt.Errorf("error al adjuntar %q: %v", att.Name, err)
+		}
+	}
+
+	if err := mixedWriter.Close(); err != nil {
+		return nil, fmt.Errorf("error al cerrar multipart/mixed: %v", err)
+	}
+
+	var out bytes.Buffer
+	writeHeaderLine(&out, "From", msg.From)
+	writeHeaderLine(&out, "To", strings.Join(msg.To, ", "))
+	if len(msg.Cc) > 0 {
+		writeHeaderLine(&out, "Cc", strings.Join(msg.Cc, ", "))
+	}
+	if msg.ReplyTo != "" {
+		writeHeaderLine(&out, "Reply-To", msg.ReplyTo)
+	}
+	writeHeaderLine(&out, "Subject", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	writeHeaderLine(&out, "MIME-Version", "1.0")
+	writeHeaderLine(&out, "Content-Type", fmt.Sprintf("multipart/mixed; boundary=%s", mixedWriter.Boundary()))
+	out.WriteString("\r\n")
+	out.Write(mixedBuf.Bytes())
+
+	return out.Bytes(), nil
+}
+
+func writeHeaderLine(out *bytes.Buffer, name, value string) {
+	fmt.Fprintf(out, "%s: %s\r\n", name, value)
+}
+
+func writeTextPart(w *multipart.Writer, contentType, body string) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", fmt.Sprintf("%s; charset=UTF-8", contentType))
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func writeAttachment(w *multipart.Writer, att Attachment) error {
+	contentType := att.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	disposition := "attachment"
+	if att.Inline {
+		disposition = "inline"
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", fmt.Sprintf(`%s; filename="%s"`, disposition, att.Name))
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	encoder := base64LineWriter{w: part}
+	_, err = encoder.Write(att.Data)
+	return err
+}
@@ -0,0 +1,163 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DKIMConfig habilita la firma DKIM (rsa-sha256, canonicalización
+// relaxed/relaxed) cuando sus tres campos están completos.
+type DKIMConfig struct {
+	PrivateKeyPath string
+	Domain         string
+	Selector       string
+}
+
+func (c DKIMConfig) enabled() bool {
+	return c.PrivateKeyPath != "" && c.Domain != "" && c.Selector != ""
+}
+
+// dkimSignedHeaders son los encabezados de sobre que firmamos; deben existir
+// todos en el mensaje construido por buildRaw.
+var dkimSignedHeaders = []string{"From", "To", "Subject", "MIME-Version", "Content-Type"}
+
+// signDKIM firma raw (un mensaje RFC 822 completo: encabezados + \r\n\r\n +
+// cuerpo) y devuelve el mensaje con un encabezado DKIM-Signature prepended.
+func signDKIM(cfg DKIMConfig, raw []byte) ([]byte, error) {
+	keyData, err := os.ReadFile(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer la clave privada DKIM: %v", err)
+	}
+	privateKey, err := parseRSAPrivateKey(keyData)
+	if err != nil {
+		return nil, fmt.Errorf("error al parsear la clave privada DKIM: %v", err)
+	}
+
+	headerBlock, body := splitMessage(raw)
+
+	bodyHash := sha256.Sum256(canonicalizeBodyRelaxed(body))
+	bh := base64.StdEncoding.EncodeToString(bodyHash[:])
+
+	signedHeaderNames := strings.Join(dkimSignedHeaders, ":")
+	dkimHeaderPrefix := fmt.Sprintf(
+		"v=1; a=rsa-sha256; c=relaxed/relaxed; d=%s; s=%s; h=%s; bh=%s; b=",
+		cfg.Domain, cfg.Selector, signedHeaderNames, bh,
+	)
+
+	// El propio encabezado DKIM-Signature se incluye sin el CRLF final que
+	// añade canonicalizeHeaderValue (RFC 6376 §3.7): es el último encabezado
+	// firmado y el hash no debe cubrir la línea en blanco que lo seguiría.
+	canonHeaders := canonicalizeHeadersRelaxed(headerBlock, dkimSignedHeaders)
+	canonHeaders += "dkim-signature:" + strings.TrimSuffix(canonicalizeHeaderValue(dkimHeaderPrefix), "\r\n")
+
+	hashed := sha256.Sum256([]byte(canonHeaders))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return nil, fmt.Errorf("error al firmar con la clave DKIM: %v", err)
+	}
+	b := base64.StdEncoding.EncodeToString(signature)
+
+	dkimHeader := fmt.Sprintf("DKIM-Signature: %s%s\r\n", dkimHeaderPrefix, b)
+
+	var out bytes.Buffer
+	out.WriteString(dkimHeader)
+	out.Write(raw)
+	return out.Bytes(), nil
+}
+
+func parseRSAPrivateKey(pemData []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("no se encontró un bloque PEM válido")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("la clave privada no es RSA")
+	}
+	return rsaKey, nil
+}
+
+func splitMessage(raw []byte) (headerBlock, body []byte) {
+	idx := bytes.Index(raw, []byte("\r\n\r\n"))
+	if idx == -1 {
+		return raw, nil
+	}
+	return raw[:idx], raw[idx+4:]
+}
+
+var headerFoldRegex = regexp.MustCompile(`\r\n[ \t]+`)
+
+// canonicalizeHeadersRelaxed implementa la canonicalización "relaxed" de
+// encabezados de RFC 6376 §3.4.2 para los headers en names, en ese orden,
+// tomando la última instancia de cada uno si se repite.
+func canonicalizeHeadersRelaxed(headerBlock []byte, names []string) string {
+	unfolded := headerFoldRegex.ReplaceAll(headerBlock, []byte(" "))
+	lines := strings.Split(string(unfolded), "\r\n")
+
+	values := make(map[string]string)
+	for _, line := range lines {
+		colon := strings.Index(line, ":")
+		if colon == -1 {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(line[:colon]))
+		values[name] = line[colon+1:]
+	}
+
+	var out strings.Builder
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		out.WriteString(lower)
+		out.WriteString(":")
+		out.WriteString(canonicalizeHeaderValue(values[lower]))
+	}
+	return out.String()
+}
+
+func canonicalizeHeaderValue(value string) string {
+	collapsed := regexp.MustCompile(`[ \t]+`).ReplaceAllString(strings.TrimSpace(value), " ")
+	return collapsed + "\r\n"
+}
+
+// canonicalizeBodyRelaxed implementa la canonicalización "relaxed" de
+// cuerpo de RFC 6376 §3.4.4: colapsa los espacios/tabs internos, recorta
+// los espacios al final de cada línea, y reduce las líneas vacías finales a
+// un único CRLF (un cuerpo vacío se canonicaliza a un único CRLF).
+func canonicalizeBodyRelaxed(body []byte) []byte {
+	normalized := strings.ReplaceAll(string(body), "\r\n", "\n")
+	lines := strings.Split(normalized, "\n")
+
+	for i, line := range lines {
+		collapsed := regexp.MustCompile(`[ \t]+`).ReplaceAllString(line, " ")
+		lines[i] = strings.TrimRight(collapsed, " \t")
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return []byte("\r\n")
+	}
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
@@ -0,0 +1,208 @@
+package mailer
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStripHTMLTagsProducesPlainText(t *testing.T) {
+	html := `<html><body><h1>Hola</h1><p>Línea uno</p><p>Línea dos &amp; más</p></body></html>`
+	text := stripHTMLTags(html)
+
+	if strings.Contains(text, "<") {
+		t.Errorf("stripHTMLTags() no debería dejar etiquetas: %q", text)
+	}
+	if !strings.Contains(text, "Hola") || !strings.Contains(text, "Línea uno") {
+		t.Errorf("stripHTMLTags() perdió contenido: %q", text)
+	}
+	if !strings.Contains(text, "Línea dos & más") {
+		t.Errorf("stripHTMLTags() no des-escapó entidades: %q", text)
+	}
+}
+
+func TestBuildRawProducesMultipartMixedWithAlternativeAndAttachment(t *testing.T) {
+	msg := Message{
+		From:    "bot@example.com",
+		To:      []string{"destino@example.com"},
+		Subject: "Asunto con ñ",
+		HTML:    "<p>Hola <b>mundo</b></p>",
+		Attachments: []Attachment{
+			{Name: "nota.txt", ContentType: "text/plain", Data: []byte("contenido del adjunto")},
+		},
+	}
+
+	raw, err := buildRaw(msg)
+	if err != nil {
+		t.Fatalf("buildRaw() error = %v", err)
+	}
+	out := string(raw)
+
+	if !strings.Contains(out, "Content-Type: multipart/mixed") {
+		t.Error("se esperaba un Content-Type multipart/mixed en el sobre")
+	}
+	if !strings.Contains(out, "multipart/alternative") {
+		t.Error("se esperaba una parte multipart/alternative")
+	}
+	if !strings.Contains(out, `filename="nota.txt"`) {
+		t.Error("se esperaba el adjunto con su filename")
+	}
+	if !strings.Contains(out, "text/plain; charset=UTF-8") {
+		t.Error("se esperaba una parte text/plain")
+	}
+	if !strings.Contains(out, "text/html; charset=UTF-8") {
+		t.Error("se esperaba una parte text/html")
+	}
+	if !strings.Contains(out, "=?UTF-8?") {
+		t.Error("se esperaba el Subject codificado en RFC 2047 por los caracteres no-ASCII")
+	}
+}
+
+func TestSMTPConfigResolveTLSMode(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  SMTPConfig
+		want TLSMode
+	}{
+		{"puerto 465 implícito por defecto", SMTPConfig{Port: "465"}, TLSImplicit},
+		{"puerto 587 usa starttls por defecto", SMTPConfig{Port: "587"}, TLSStartTLS},
+		{"modo explícito gana sobre el puerto", SMTPConfig{Port: "465", TLSMode: TLSNone}, TLSNone},
+	}
+
+	for _, tt := range tests {
+		if got := tt.cfg.resolveTLSMode(); got != tt.want {
+			t.Errorf("%s: resolveTLSMode() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func generateTestKey(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("error al generar la clave de prueba: %v", err)
+	}
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	path := filepath.Join(t.TempDir(), "dkim.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatalf("error al escribir la clave de prueba: %v", err)
+	}
+	return path
+}
+
+func TestSignDKIMPrependsSignatureHeader(t *testing.T) {
+	keyPath := generateTestKey(t)
+
+	msg := Message{
+		From:    "bot@example.com",
+		To:      []string{"destino@example.com"},
+		Subject: "Asunto de prueba",
+		HTML:    "<p>Hola</p>",
+	}
+	raw, err := buildRaw(msg)
+	if err != nil {
+		t.Fatalf("buildRaw() error = %v", err)
+	}
+
+	signed, err := signDKIM(DKIMConfig{PrivateKeyPath: keyPath, Domain: "example.com", Selector: "default"}, raw)
+	if err != nil {
+		t.Fatalf("signDKIM() error = %v", err)
+	}
+
+	out := string(signed)
+	if !strings.HasPrefix(out, "DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=example.com; s=default;") {
+		t.Errorf("el encabezado DKIM-Signature no tiene el formato esperado: %q", out[:120])
+	}
+	if !strings.Contains(out, "bh=") || !strings.Contains(out, "b=") {
+		t.Error("se esperaban los campos bh= y b= en la firma")
+	}
+}
+
+func TestSignDKIMProducesVerifiableSignature(t *testing.T) {
+	keyPath := generateTestKey(t)
+	keyData, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("error al leer la clave de prueba: %v", err)
+	}
+	block, _ := pem.Decode(keyData)
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("error al parsear la clave de prueba: %v", err)
+	}
+
+	msg := Message{
+		From:    "bot@example.com",
+		To:      []string{"destino@example.com"},
+		Subject: "Asunto de prueba",
+		HTML:    "<p>Hola</p>",
+	}
+	raw, err := buildRaw(msg)
+	if err != nil {
+		t.Fatalf("buildRaw() error = %v", err)
+	}
+
+	signed, err := signDKIM(DKIMConfig{PrivateKeyPath: keyPath, Domain: "example.com", Selector: "default"}, raw)
+	if err != nil {
+		t.Fatalf("signDKIM() error = %v", err)
+	}
+
+	headerBlock, _ := splitMessage(signed)
+	lines := strings.Split(string(headerBlock), "\r\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "DKIM-Signature: ") {
+		t.Fatalf("se esperaba que DKIM-Signature fuera el primer encabezado, got %q", headerBlock)
+	}
+	dkimHeaderValue := strings.TrimPrefix(lines[0], "DKIM-Signature: ")
+
+	bIdx := strings.LastIndex(dkimHeaderValue, "; b=")
+	if bIdx == -1 {
+		t.Fatalf("no se encontró el campo b= en %q", dkimHeaderValue)
+	}
+	sigB64 := dkimHeaderValue[bIdx+len("; b="):]
+	signature, err := base64.StdEncoding.DecodeString(sigB64)
+	if err != nil {
+		t.Fatalf("la firma b= no es base64 válido: %v", err)
+	}
+	dkimHeaderPrefix := dkimHeaderValue[:bIdx+len("; b=")]
+
+	_, body := splitMessage(raw)
+	bodyHash := sha256.Sum256(canonicalizeBodyRelaxed(body))
+	bh := base64.StdEncoding.EncodeToString(bodyHash[:])
+	if !strings.Contains(dkimHeaderPrefix, "bh="+bh) {
+		t.Fatalf("bh= no coincide con el hash del cuerpo canonicalizado")
+	}
+
+	origHeaderBlock, _ := splitMessage(raw)
+	canonHeaders := canonicalizeHeadersRelaxed(origHeaderBlock, dkimSignedHeaders)
+	canonHeaders += "dkim-signature:" + strings.TrimSuffix(canonicalizeHeaderValue(dkimHeaderPrefix), "\r\n")
+	hashed := sha256.Sum256([]byte(canonHeaders))
+
+	if err := rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA256, hashed[:], signature); err != nil {
+		t.Errorf("la firma DKIM no verifica contra la clave pública: %v", err)
+	}
+}
+
+func TestCanonicalizeBodyRelaxedCollapsesWhitespaceAndTrailingBlankLines(t *testing.T) {
+	body := []byte("Hola   mundo  \r\nSegunda línea\r\n\r\n\r\n")
+	got := string(canonicalizeBodyRelaxed(body))
+	want := "Hola mundo\r\nSegunda línea\r\n"
+
+	if got != want {
+		t.Errorf("canonicalizeBodyRelaxed() = %q, want %q", got, want)
+	}
+}
+
+func TestCanonicalizeBodyRelaxedEmptyBody(t *testing.T) {
+	got := string(canonicalizeBodyRelaxed(nil))
+	if got != "\r\n" {
+		t.Errorf("canonicalizeBodyRelaxed(nil) = %q, want CRLF", got)
+	}
+}
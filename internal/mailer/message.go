@@ -0,0 +1,35 @@
+// Package mailer construye y envía correos MIME completos (texto plano +
+// HTML, adjuntos, firma DKIM opcional) sobre SMTP, en reemplazo de construir
+// los encabezados a mano con net/smtp.
+package mailer
+
+// Attachment es un archivo adjunto (o inline) de un Message.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+	Inline      bool
+}
+
+// Message es un correo a enviar, independiente del transporte.
+type Message struct {
+	From    string
+	To      []string
+	Cc      []string
+	Bcc     []string
+	ReplyTo string
+	Subject string
+
+	// HTML es el cuerpo en text/html.
+	HTML string
+	// Text es el cuerpo en text/plain. Si está vacío, se genera a partir de
+	// HTML quitando las etiquetas.
+	Text string
+
+	Attachments []Attachment
+}
+
+// Mailer envía un Message ya construido.
+type Mailer interface {
+	Send(msg Message) error
+}
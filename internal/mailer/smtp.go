@@ -0,0 +1,132 @@
+package mailer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/smtp"
+)
+
+// TLSMode controla cómo SMTPMailer establece la conexión con el servidor.
+type TLSMode string
+
+const (
+	// TLSImplicit abre la conexión ya sobre TLS (puerto 465 típicamente).
+	TLSImplicit TLSMode = "implicit"
+	// TLSStartTLS abre una conexión en texto plano y la asciende con
+	// STARTTLS (puerto 587/25 típicamente).
+	TLSStartTLS TLSMode = "starttls"
+	// TLSNone no usa TLS en absoluto (solo para pruebas/loopback).
+	TLSNone TLSMode = "none"
+)
+
+// SMTPConfig es la configuración de transporte de SMTPMailer.
+type SMTPConfig struct {
+	Host string
+	Port string
+	User string
+	Pass string
+
+	// TLSMode fuerza el modo de TLS. Si está vacío, se elige
+	// automáticamente por el puerto (465 -> implicit, cualquier otro ->
+	// starttls).
+	TLSMode TLSMode
+
+	DKIM DKIMConfig
+}
+
+func (c SMTPConfig) resolveTLSMode() TLSMode {
+	if c.TLSMode != "" {
+		return c.TLSMode
+	}
+	if c.Port == "465" {
+		return TLSImplicit
+	}
+	return TLSStartTLS
+}
+
+// SMTPMailer envía Message sobre SMTP, construyendo el MIME completo
+// (texto + HTML + adjuntos) y firmando con DKIM si está configurado.
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer crea un Mailer que envía sobre el servidor SMTP descrito
+// por cfg.
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+func (m *SMTPMailer) Send(msg Message) error {
+	raw, err := buildRaw(msg)
+	if err != nil {
+		return fmt.Errorf("error al construir el mensaje: %v", err)
+	}
+
+	if m.cfg.DKIM.enabled() {
+		raw, err = signDKIM(m.cfg.DKIM, raw)
+		if err != nil {
+			return fmt.Errorf("error al firmar con DKIM: %v", err)
+		}
+	}
+
+	recipients := append(append([]string{}, msg.To...), msg.Cc...)
+	recipients = append(recipients, msg.Bcc...)
+
+	addr := fmt.Sprintf("%s:%s", m.cfg.Host, m.cfg.Port)
+	auth := smtp.PlainAuth("", m.cfg.User, m.cfg.Pass, m.cfg.Host)
+
+	client, err := m.dial(addr)
+	if err != nil {
+		return fmt.Errorf("error al conectar con el servidor SMTP: %v", err)
+	}
+	defer client.Close()
+
+	if mode := m.cfg.resolveTLSMode(); mode == TLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: m.cfg.Host}); err != nil {
+				return fmt.Errorf("error al iniciar STARTTLS: %v", err)
+			}
+		}
+	}
+
+	if m.cfg.User != "" {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("error de autenticación SMTP: %v", err)
+		}
+	}
+
+	if err := client.Mail(m.cfg.User); err != nil {
+		return fmt.Errorf("error al establecer remitente: %v", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("error al establecer destinatario %q: %v", recipient, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("error al iniciar datos: %v", err)
+	}
+	if _, err := writer.Write(raw); err != nil {
+		return fmt.Errorf("error al escribir el mensaje: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("error al cerrar el escritor de datos: %v", err)
+	}
+
+	return client.Quit()
+}
+
+func (m *SMTPMailer) dial(addr string) (*smtp.Client, error) {
+	switch m.cfg.resolveTLSMode() {
+	case TLSImplicit:
+		conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: m.cfg.Host})
+		if err != nil {
+			return nil, err
+		}
+		return smtp.NewClient(conn, m.cfg.Host)
+	default:
+		return smtp.Dial(addr)
+	}
+}
@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlBreakRegex  = regexp.MustCompile(`(?i)<(br|/p|/div|/tr|/li)\s*/?>`)
+	htmlAnyTagRegex = regexp.MustCompile(`<[^>]*>`)
+	blankLinesRegex = regexp.MustCompile(`\n{3,}`)
+)
+
+// stripHTMLTags genera una alternativa en texto plano razonable a partir de
+// un cuerpo HTML: quita <script>/<style> completos, convierte saltos de
+// bloque en saltos de línea, elimina el resto de etiquetas y des-escapa
+// entidades HTML.
+func stripHTMLTags(input string) string {
+	withoutScripts := regexp.MustCompile(`(?is)<script[^>]*>.*?</script>`).ReplaceAllString(input, "")
+	withoutStyles := regexp.MustCompile(`(?is)<style[^>]*>.*?</style>`).ReplaceAllString(withoutScripts, "")
+	withBreaks := htmlBreakRegex.ReplaceAllString(withoutStyles, "\n")
+	withoutTags := htmlAnyTagRegex.ReplaceAllString(withBreaks, "")
+	unescaped := html.UnescapeString(withoutTags)
+
+	lines := strings.Split(unescaped, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	collapsed := blankLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(collapsed)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -8,6 +9,8 @@ import (
 	"net/http"
 	"net/smtp"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,34 +26,299 @@ func sendJSONError(w http.ResponseWriter, message string, status int) {
 
 // --- Rate Limiter Implementation ---
 
-// visitor struct holds a rate limiter and the last time it was seen.
-type visitor struct {
+// limiterEntry holds a rate limiter and the last time it was used.
+type limiterEntry struct {
 	limiter  *rate.Limiter
 	lastSeen time.Time
 }
 
-// Use a mutex to protect the visitors map from concurrent access.
-var visitors = make(map[string]*visitor)
-var mu sync.Mutex
+// limiterSet keeps one rate.Limiter per key (e.g. per IP, per email, or per
+// destination address) so a single dimension can be throttled independently
+// of the others.
+type limiterSet struct {
+	every time.Duration
+	burst int
+	ttl   time.Duration
 
-// getVisitorLimiter retrieves or creates a rate limiter for a given IP.
-func getVisitorLimiter(ip string) *rate.Limiter {
-	mu.Lock()
-	defer mu.Unlock()
+	mu       sync.Mutex
+	limiters map[string]*limiterEntry
+}
+
+// newLimiterSet creates a limiterSet with the given rate, burst and idle TTL
+// used by cleanup to evict stale entries.
+func newLimiterSet(every time.Duration, burst int, ttl time.Duration) *limiterSet {
+	return &limiterSet{
+		every:    every,
+		burst:    burst,
+		ttl:      ttl,
+		limiters: make(map[string]*limiterEntry),
+	}
+}
+
+// get retrieves or creates the rate.Limiter for key.
+func (s *limiterSet) get(key string) *rate.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	v, exists := visitors[ip]
+	e, exists := s.limiters[key]
 	if !exists {
-		// Allow 1 request every 10 seconds, with a burst of 3.
-		limiter := rate.NewLimiter(rate.Every(10*time.Second), 3)
-		visitors[ip] = &visitor{limiter, time.Now()}
-		return limiter
+		e = &limiterEntry{limiter: rate.NewLimiter(rate.Every(s.every), s.burst)}
+		s.limiters[key] = e
+	}
+	e.lastSeen = time.Now()
+	return e.limiter
+}
+
+// cleanup evicts entries that have been idle longer than ttl.
+func (s *limiterSet) cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, e := range s.limiters {
+		if time.Since(e.lastSeen) > s.ttl {
+			delete(s.limiters, key)
+		}
+	}
+}
+
+// limiterConfig is the rate/burst pair read from environment variables.
+type limiterConfig struct {
+	every time.Duration
+	burst int
+}
+
+// envLimiterConfig reads "<prefix>_INTERVAL" (a time.Duration string) and
+// "<prefix>_BURST" (an int), falling back to def for any value that is
+// unset or invalid.
+func envLimiterConfig(prefix string, def limiterConfig) limiterConfig {
+	cfg := def
+	if v := os.Getenv(prefix + "_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.every = d
+		}
+	}
+	if v := os.Getenv(prefix + "_BURST"); v != "" {
+		if b, err := strconv.Atoi(v); err == nil {
+			cfg.burst = b
+		}
+	}
+	return cfg
+}
+
+// --- Mail delivery ---
+//
+// main.go is the standalone net/http server entrypoint (local dev / non-
+// serverless deployment); api/ holds the Vercel-style serverless function
+// handlers (package handler, one exported handler per file). The two run
+// as separate processes with no shared state, so this file keeps its own
+// Mailer/rate limiter/notifier fan-out instead of importing api/ or
+// internal/mailer directly. That split is intentional for now, not an
+// oversight — unifying both entrypoints onto api/'s internal/mailer-based
+// delivery path is tracked as follow-up work, not part of this change set.
+
+// Message representa el correo a enviar, independiente del transporte usado.
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer abstrae el envío de correos para que el camino de entrega sea
+// intercambiable (SMTP real, no-op en desarrollo, mock en tests).
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SmtpConfig contiene la configuración SMTP.
+type SmtpConfig struct {
+	Host    string
+	Port    string
+	User    string
+	Pass    string
+	ToEmail string
+}
+
+// newSmtpConfig lee la configuración SMTP desde variables de entorno.
+func newSmtpConfig() SmtpConfig {
+	return SmtpConfig{
+		Host:    os.Getenv("SMTP_HOST"),
+		Port:    os.Getenv("SMTP_PORT"),
+		User:    os.Getenv("SMTP_USER"),
+		Pass:    os.Getenv("SMTP_PASS"),
+		ToEmail: "grajajhon9@gmail.com", // Correo de destino
+	}
+}
+
+// complete informa si hay suficiente configuración para enviar correo real.
+func (c SmtpConfig) complete() bool {
+	return c.Host != "" && c.Port != "" && c.User != "" && c.Pass != ""
+}
+
+// SMTPMailer envía correos usando smtp.SendMail con autenticación PLAIN,
+// igual que hacía el contactHandler original.
+type SMTPMailer struct {
+	cfg SmtpConfig
+}
+
+// NewSMTPMailer crea un Mailer que entrega correos por SMTP real.
+func NewSMTPMailer(cfg SmtpConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	auth := smtp.PlainAuth("", m.cfg.User, m.cfg.Pass, m.cfg.Host)
+
+	// Construir un cuerpo de correo más robusto con cabeceras MIME para evitar filtros de spam.
+	headers := "MIME-version: 1.0;\nContent-Type: text/plain; charset=\"UTF-8\";\n"
+	fromHeader := fmt.Sprintf("From: %s\r\n", msg.From)
+	toHeader := fmt.Sprintf("To: %s\r\n", msg.To)
+	subjectHeader := fmt.Sprintf("Subject: %s\r\n", msg.Subject)
+
+	emailBody := fromHeader + toHeader + subjectHeader + headers + "\r\n" + msg.Body
+
+	smtpAddr := fmt.Sprintf("%s:%s", m.cfg.Host, m.cfg.Port)
+
+	return smtp.SendMail(smtpAddr, auth, m.cfg.User, []string{msg.To}, []byte(emailBody))
+}
+
+// NullMailer registra el correo y lo descarta. Se usa en desarrollo local
+// cuando las variables de entorno SMTP no están definidas.
+type NullMailer struct{}
+
+func (NullMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("NullMailer: SMTP no configurado, descartando correo para %q (asunto: %q)", msg.To, msg.Subject)
+	return nil
+}
+
+// MockMailer registra cada correo enviado para que los tests puedan hacer
+// aserciones sobre ellos, sin tocar la red.
+type MockMailer struct {
+	mu      sync.Mutex
+	Sent    []Message
+	SendErr error
+}
+
+func (m *MockMailer) Send(ctx context.Context, msg Message) error {
+	if m.SendErr != nil {
+		return m.SendErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Sent = append(m.Sent, msg)
+	return nil
+}
+
+// --- Server ---
+
+// Server agrupa las dependencias del servidor HTTP (mailer, configuración y
+// el estado del rate limiter) en lugar de depender de variables globales.
+type Server struct {
+	mailer    Mailer
+	cfg       SmtpConfig
+	notifiers []Notifier
+
+	// ipLimiters, emailLimiters y globalLimiters throttle, respectivamente,
+	// por IP de origen, por email normalizado del remitente y por
+	// dirección de destino, como backstop global.
+	ipLimiters     *limiterSet
+	emailLimiters  *limiterSet
+	globalLimiters *limiterSet
+}
+
+// NewServer construye un Server listo para registrar sus handlers. Los
+// notifiers son opcionales: si se omiten, solo se entrega por SMTP.
+func NewServer(mailer Mailer, cfg SmtpConfig, notifiers ...Notifier) *Server {
+	const limiterTTL = 3 * time.Minute
+
+	ipCfg := envLimiterConfig("RL_IP", limiterConfig{every: 10 * time.Second, burst: 3})
+	emailCfg := envLimiterConfig("RL_EMAIL", limiterConfig{every: 30 * time.Second, burst: 3})
+	globalCfg := envLimiterConfig("RL_GLOBAL", limiterConfig{every: time.Second, burst: 20})
+
+	return &Server{
+		mailer:         mailer,
+		cfg:            cfg,
+		notifiers:      notifiers,
+		ipLimiters:     newLimiterSet(ipCfg.every, ipCfg.burst, limiterTTL),
+		emailLimiters:  newLimiterSet(emailCfg.every, emailCfg.burst, limiterTTL),
+		globalLimiters: newLimiterSet(globalCfg.every, globalCfg.burst, limiterTTL),
+	}
+}
+
+// dispatch entrega msg por SMTP y por cada notificador configurado, todos en
+// paralelo y con un timeout individual, y considera la entrega exitosa si al
+// menos uno de los canales tuvo éxito. Los fallos de los demás solo se
+// registran, para que una caída de SMTP no impida que el contacto llegue por
+// Telegram o Slack (y viceversa).
+func (s *Server) dispatch(ctx context.Context, msg Message) error {
+	type channel struct {
+		name string
+		send func(context.Context, Message) error
+	}
+
+	channels := make([]channel, 0, 1+len(s.notifiers))
+	channels = append(channels, channel{name: "smtp", send: s.mailer.Send})
+	for i, n := range s.notifiers {
+		channels = append(channels, channel{name: fmt.Sprintf("notifier-%d", i), send: n.Notify})
+	}
+
+	errs := make([]error, len(channels))
+	var wg sync.WaitGroup
+	for i, ch := range channels {
+		wg.Add(1)
+		go func(i int, ch channel) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, notifierTimeout)
+			defer cancel()
+			errs[i] = ch.send(ctx, msg)
+		}(i, ch)
+	}
+	wg.Wait()
+
+	succeeded := false
+	for i, err := range errs {
+		if err != nil {
+			log.Printf("Error al entregar el contacto vía %s: %v", channels[i].name, err)
+			continue
+		}
+		succeeded = true
 	}
 
-	v.lastSeen = time.Now()
-	return v.limiter
+	if !succeeded {
+		return fmt.Errorf("todos los canales de entrega fallaron")
+	}
+	return nil
 }
 
-func contactHandler(w http.ResponseWriter, r *http.Request) {
+// reserveSend intenta tomar un token de los tres limitadores a la vez. Si
+// alguno lo niega, cancela las reservas ya concedidas y devuelve el mayor
+// retraso exigido por los limitadores que fallaron (el más estricto).
+func (s *Server) reserveSend(ip, email, toEmail string) (retryAfter time.Duration, allowed bool) {
+	reservations := []*rate.Reservation{
+		s.ipLimiters.get(ip).Reserve(),
+		s.emailLimiters.get(email).Reserve(),
+		s.globalLimiters.get(toEmail).Reserve(),
+	}
+
+	allowed = true
+	for _, res := range reservations {
+		if delay := res.Delay(); delay > 0 {
+			allowed = false
+			if delay > retryAfter {
+				retryAfter = delay
+			}
+		}
+	}
+
+	if !allowed {
+		for _, res := range reservations {
+			res.Cancel()
+		}
+	}
+
+	return retryAfter, allowed
+}
+
+func (s *Server) contactHandler(w http.ResponseWriter, r *http.Request) {
 	ip, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
 		sendJSONError(w, "Error al identificar la dirección IP.", http.StatusInternalServerError)
@@ -67,13 +335,6 @@ func contactHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check the rate limiter for the current IP.
-	limiter := getVisitorLimiter(ip)
-	if !limiter.Allow() {
-		sendJSONError(w, "Has enviado demasiadas solicitudes. Por favor, espera un momento.", http.StatusTooManyRequests)
-		return
-	}
-
 	name := r.FormValue("name")
 	email := r.FormValue("email")
 	message := r.FormValue("message")
@@ -83,39 +344,28 @@ func contactHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	smtpHost := os.Getenv("SMTP_HOST")
-	smtpPort := os.Getenv("SMTP_PORT")
-	smtpUser := os.Getenv("SMTP_USER")
-	smtpPass := os.Getenv("SMTP_PASS")
-
-	toEmail := "grajajhon9@gmail.com" // Correo de destino
+	normalizedEmail := strings.ToLower(strings.TrimSpace(email))
 
-	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
-		log.Println("Error: Configuración SMTP incompleta. Define las variables de entorno SMTP_HOST, SMTP_PORT, SMTP_USER, SMTP_PASS.")
-		sendJSONError(w, "Error de configuración del servidor para enviar el correo.", http.StatusInternalServerError)
+	// Consumir un token de las tres dimensiones (IP, email, destino) antes
+	// de intentar el envío.
+	if retryAfter, allowed := s.reserveSend(ip, normalizedEmail, s.cfg.ToEmail); !allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+		sendJSONError(w, "Has enviado demasiadas solicitudes. Por favor, espera un momento.", http.StatusTooManyRequests)
 		return
 	}
 
-	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
-
-	// Construir un cuerpo de correo más robusto con cabeceras MIME para evitar filtros de spam.
-	headers := "MIME-version: 1.0;\nContent-Type: text/plain; charset=\"UTF-8\";\n"
-	fromHeader := fmt.Sprintf("From: Softex Labs Contacto <%s>\r\n", smtpUser)
-	toHeader := fmt.Sprintf("To: %s\r\n", toEmail)
-	subjectHeader := "Subject: Nuevo Mensaje de Contacto - Softex Labs\r\n"
-
-	msgBody := fmt.Sprintf("Has recibido un nuevo mensaje desde tu sitio web:\n\n"+
-		"Nombre: %s\n"+
-		"Email de Contacto: %s\n\n"+
-		"Mensaje:\n%s\n", name, email, message)
-
-	emailBody := fromHeader + toHeader + subjectHeader + headers + "\r\n" + msgBody
-
-	smtpAddr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+	msg := Message{
+		From:    fmt.Sprintf("Softex Labs Contacto <%s>", s.cfg.User),
+		To:      s.cfg.ToEmail,
+		Subject: "Nuevo Mensaje de Contacto - Softex Labs",
+		Body: fmt.Sprintf("Has recibido un nuevo mensaje desde tu sitio web:\n\n"+
+			"Nombre: %s\n"+
+			"Email de Contacto: %s\n\n"+
+			"Mensaje:\n%s\n", name, email, message),
+	}
 
-	err = smtp.SendMail(smtpAddr, auth, smtpUser, []string{toEmail}, []byte(emailBody))
-	if err != nil {
-		log.Printf("Error al enviar el correo: %v", err)
+	if err := s.dispatch(r.Context(), msg); err != nil {
+		log.Printf("Error al entregar el contacto: %v", err)
 		sendJSONError(w, "Hubo un error interno al intentar enviar el correo.", http.StatusInternalServerError)
 		return
 	}
@@ -126,17 +376,13 @@ func contactHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"message": "¡Mensaje enviado con éxito!"})
 }
 
-// Periodically clean up old entries from the visitors map.
-func cleanupVisitors() {
+// Periodically clean up idle entries from every limiter set.
+func (s *Server) cleanupVisitors() {
 	for {
 		time.Sleep(1 * time.Minute)
-		mu.Lock()
-		for ip, v := range visitors {
-			if time.Since(v.lastSeen) > 3*time.Minute {
-				delete(visitors, ip)
-			}
-		}
-		mu.Unlock()
+		s.ipLimiters.cleanup()
+		s.emailLimiters.cleanup()
+		s.globalLimiters.cleanup()
 	}
 }
 
@@ -148,10 +394,21 @@ func main() {
 		log.Println("Advertencia: No se pudo cargar el archivo .env. Se usarán las variables de entorno del sistema si existen.")
 	}
 
-	go cleanupVisitors()
+	cfg := newSmtpConfig()
+
+	var mailer Mailer
+	if cfg.complete() {
+		mailer = NewSMTPMailer(cfg)
+	} else {
+		log.Println("Advertencia: Configuración SMTP incompleta. Se usará NullMailer y los correos no se enviarán realmente.")
+		mailer = NullMailer{}
+	}
+
+	server := NewServer(mailer, cfg, notifiersFromEnv()...)
+	go server.cleanupVisitors()
 
 	// Primero el endpoint para el formulario de contacto.
-	http.HandleFunc("/api/contact", contactHandler)
+	http.HandleFunc("/api/contact", server.contactHandler)
 
 	// Luego el servidor de archivos estáticos.
 	fs := http.FileServer(http.Dir("."))
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestServer() (*Server, *MockMailer) {
+	mailer := &MockMailer{}
+	cfg := SmtpConfig{
+		Host:    "smtp.example.com",
+		Port:    "587",
+		User:    "contacto@softex-labs.xyz",
+		Pass:    "secret",
+		ToEmail: "grajajhon9@gmail.com",
+	}
+	return NewServer(mailer, cfg), mailer
+}
+
+func postContact(t *testing.T, s *Server, form url.Values, remoteAddr string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/contact", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.RemoteAddr = remoteAddr
+
+	rec := httptest.NewRecorder()
+	s.contactHandler(rec, req)
+	return rec
+}
+
+func TestContactHandlerSendsMessageViaMailer(t *testing.T) {
+	server, mailer := newTestServer()
+
+	form := url.Values{
+		"name":    {"Juan Pérez"},
+		"email":   {"juan@example.com"},
+		"message": {"Hola, quiero más información."},
+	}
+
+	rec := postContact(t, server, form, "203.0.113.1:12345")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("se esperaba status 200, se obtuvo %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if len(mailer.Sent) != 1 {
+		t.Fatalf("se esperaba 1 correo enviado, se obtuvieron %d", len(mailer.Sent))
+	}
+
+	sent := mailer.Sent[0]
+	if sent.To != server.cfg.ToEmail {
+		t.Errorf("destinatario incorrecto: se esperaba %q, se obtuvo %q", server.cfg.ToEmail, sent.To)
+	}
+	if sent.Subject != "Nuevo Mensaje de Contacto - Softex Labs" {
+		t.Errorf("asunto incorrecto: %q", sent.Subject)
+	}
+	if !strings.Contains(sent.From, server.cfg.User) {
+		t.Errorf("el remitente debería incluir la cuenta SMTP, se obtuvo %q", sent.From)
+	}
+	if !strings.Contains(sent.Body, "Juan Pérez") || !strings.Contains(sent.Body, "juan@example.com") {
+		t.Errorf("el cuerpo del correo no contiene los datos del formulario: %q", sent.Body)
+	}
+}
+
+func TestContactHandlerMissingFieldsNeverCallsMailer(t *testing.T) {
+	server, mailer := newTestServer()
+
+	form := url.Values{
+		"name":    {"Juan Pérez"},
+		"email":   {""},
+		"message": {"Hola"},
+	}
+
+	rec := postContact(t, server, form, "203.0.113.2:12345")
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("se esperaba status 400, se obtuvo %d", rec.Code)
+	}
+	if len(mailer.Sent) != 0 {
+		t.Errorf("no se debería haber enviado ningún correo, se enviaron %d", len(mailer.Sent))
+	}
+}
+
+func TestContactHandlerRateLimitedRequestsNeverCallMailer(t *testing.T) {
+	server, mailer := newTestServer()
+
+	form := url.Values{
+		"name":    {"Juan Pérez"},
+		"email":   {"juan@example.com"},
+		"message": {"Hola, quiero más información."},
+	}
+
+	remoteAddr := "203.0.113.3:12345"
+
+	// Agotar el burst permitido (3 solicitudes).
+	for i := 0; i < 3; i++ {
+		rec := postContact(t, server, form, remoteAddr)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("solicitud %d debería haber pasado, se obtuvo %d", i+1, rec.Code)
+		}
+	}
+
+	sentBeforeLimit := len(mailer.Sent)
+
+	rec := postContact(t, server, form, remoteAddr)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("la 4ta solicitud debería haber sido limitada, se obtuvo %d", rec.Code)
+	}
+	if len(mailer.Sent) != sentBeforeLimit {
+		t.Errorf("una solicitud limitada no debería llamar a Send: antes %d, después %d", sentBeforeLimit, len(mailer.Sent))
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("se esperaba la cabecera Retry-After en una respuesta 429")
+	}
+}
+
+func TestContactHandlerSameEmailDifferentIPsIsThrottled(t *testing.T) {
+	server, mailer := newTestServer()
+
+	form := url.Values{
+		"name":    {"Juan Pérez"},
+		"email":   {"Juan@Example.com"},
+		"message": {"Hola, quiero más información."},
+	}
+
+	// Rotar de IP en cada solicitud para saltarse el límite por IP, pero
+	// manteniendo el mismo email: el límite por email debería atraparlo.
+	for i := 0; i < 3; i++ {
+		addr := strings.Replace("203.0.113.X:12345", "X", string(rune('4'+i)), 1)
+		rec := postContact(t, server, form, addr)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("solicitud %d debería haber pasado, se obtuvo %d", i+1, rec.Code)
+		}
+	}
+
+	sentBeforeLimit := len(mailer.Sent)
+
+	rec := postContact(t, server, form, "203.0.113.9:12345")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("se esperaba 429 por límite de email, se obtuvo %d", rec.Code)
+	}
+	if len(mailer.Sent) != sentBeforeLimit {
+		t.Error("una solicitud limitada por email no debería llamar a Send")
+	}
+}
+
+func TestLimiterSetCleanupEvictsStaleEntries(t *testing.T) {
+	set := newLimiterSet(10*time.Second, 3, 0)
+	set.get("1.2.3.4")
+
+	if len(set.limiters) != 1 {
+		t.Fatalf("se esperaba 1 entrada antes de la limpieza, se obtuvieron %d", len(set.limiters))
+	}
+
+	set.cleanup()
+
+	if len(set.limiters) != 0 {
+		t.Errorf("se esperaba que la limpieza vaciara el set con ttl 0, quedaron %d entradas", len(set.limiters))
+	}
+}
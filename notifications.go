@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// notifierTimeout limita cuánto puede tardar cada notificador individual,
+// para que un canal caído no bloquee el resto de la entrega.
+const notifierTimeout = 5 * time.Second
+
+// Notifier envía el aviso de un contacto a un canal alternativo al correo
+// SMTP principal (Telegram, Slack, ...), para que los operadores sigan
+// recibiendo contactos aunque ese canal falle.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// TelegramNotifier publica el mensaje en un chat de Telegram usando la Bot API.
+type TelegramNotifier struct {
+	token  string
+	chatID string
+	client *http.Client
+}
+
+// NewTelegramNotifier crea un Notifier que publica en el chat chatID usando
+// el bot identificado por token.
+func NewTelegramNotifier(token, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{token: token, chatID: chatID, client: &http.Client{}}
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, msg Message) error {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", n.token)
+
+	payload, err := json.Marshal(map[string]string{
+		"chat_id": n.chatID,
+		"text":    fmt.Sprintf("%s\n\n%s", msg.Subject, msg.Body),
+	})
+	if err != nil {
+		return fmt.Errorf("error al construir el payload de Telegram: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error al llamar a la API de Telegram: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Telegram respondió con status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SlackNotifier publica el mensaje en un canal de Slack vía un webhook entrante.
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackNotifier crea un Notifier que publica en el webhook dado.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{webhookURL: webhookURL, client: &http.Client{}}
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", msg.Subject, msg.Body),
+	})
+	if err != nil {
+		return fmt.Errorf("error al construir el payload de Slack: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error al llamar al webhook de Slack: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Slack respondió con status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// notifiersFromEnv construye los notificadores habilitados según las
+// variables de entorno presentes.
+func notifiersFromEnv() []Notifier {
+	var notifiers []Notifier
+
+	if token, chatID := os.Getenv("TELEGRAM_BOT_TOKEN"), os.Getenv("TELEGRAM_CHAT_ID"); token != "" && chatID != "" {
+		notifiers = append(notifiers, NewTelegramNotifier(token, chatID))
+	}
+
+	if webhookURL := os.Getenv("SLACK_WEBHOOK_URL"); webhookURL != "" {
+		notifiers = append(notifiers, NewSlackNotifier(webhookURL))
+	}
+
+	return notifiers
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var errSendFailed = errors.New("smtp send failed")
+
+func TestTelegramNotifierSendsExpectedPayload(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Redirigimos las peticiones del notificador al httptest.Server en lugar
+	// de api.telegram.org, conservando el path (que incluye el token).
+	notifier := NewTelegramNotifier("test-token", "12345")
+	notifier.client = &http.Client{Transport: redirectTransport{target: server.URL}}
+
+	msg := Message{Subject: "Nuevo contacto", Body: "Hola"}
+
+	if err := notifier.Notify(context.Background(), msg); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if !strings.Contains(gotPath, "test-token") {
+		t.Errorf("la URL debería incluir el token del bot, se obtuvo %q", gotPath)
+	}
+	if gotBody["chat_id"] != "12345" {
+		t.Errorf("chat_id incorrecto: %q", gotBody["chat_id"])
+	}
+	if !strings.Contains(gotBody["text"], "Nuevo contacto") {
+		t.Errorf("el texto debería incluir el asunto: %q", gotBody["text"])
+	}
+}
+
+func TestSlackNotifierSendsExpectedPayload(t *testing.T) {
+	var gotBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(server.URL)
+	msg := Message{Subject: "Nuevo contacto", Body: "Hola desde el sitio"}
+
+	if err := notifier.Notify(context.Background(), msg); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if !strings.Contains(gotBody["text"], "Nuevo contacto") || !strings.Contains(gotBody["text"], "Hola desde el sitio") {
+		t.Errorf("el texto de Slack no contiene el mensaje esperado: %q", gotBody["text"])
+	}
+}
+
+func TestSlackNotifierNonOKStatusIsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(server.URL)
+	if err := notifier.Notify(context.Background(), Message{Subject: "x", Body: "y"}); err == nil {
+		t.Error("se esperaba un error cuando el webhook responde 500")
+	}
+}
+
+func TestDispatchSucceedsIfAnyChannelSucceeds(t *testing.T) {
+	mailer := &MockMailer{SendErr: errSendFailed}
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	succeeding := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer succeeding.Close()
+
+	server := NewServer(mailer, SmtpConfig{ToEmail: "to@example.com"},
+		NewSlackNotifier(failing.URL),
+		NewSlackNotifier(succeeding.URL),
+	)
+
+	if err := server.dispatch(context.Background(), Message{Subject: "x", Body: "y"}); err != nil {
+		t.Fatalf("dispatch() error = %v, se esperaba éxito porque un canal funcionó", err)
+	}
+}
+
+func TestDispatchFailsIfAllChannelsFail(t *testing.T) {
+	mailer := &MockMailer{SendErr: errSendFailed}
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	server := NewServer(mailer, SmtpConfig{ToEmail: "to@example.com"}, NewSlackNotifier(failing.URL))
+
+	if err := server.dispatch(context.Background(), Message{Subject: "x", Body: "y"}); err == nil {
+		t.Fatal("se esperaba un error cuando todos los canales fallan")
+	}
+}
+
+// redirectTransport reenvía todas las peticiones al host de target,
+// preservando el path, para poder apuntar un cliente HTTP a un
+// httptest.Server aunque el código bajo prueba construya URLs absolutas.
+type redirectTransport struct {
+	target string
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	targetURL, err := req.URL.Parse(t.target + req.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	req2 := req.Clone(req.Context())
+	req2.URL = targetURL
+	req2.Host = targetURL.Host
+	return http.DefaultTransport.RoundTrip(req2)
+}
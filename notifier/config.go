@@ -0,0 +1,119 @@
+package notifier
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Config describe, de forma serializable, los backends de notificación
+// habilitados. Se puede poblar desde un archivo JSON (NOTIFIER_CONFIG_FILE)
+// o inferir directamente de variables de entorno con LoadConfig.
+type Config struct {
+	Slack    *SlackConfig    `json:"slack,omitempty"`
+	Discord  *DiscordConfig  `json:"discord,omitempty"`
+	Telegram *TelegramConfig `json:"telegram,omitempty"`
+	Matrix   *MatrixConfig   `json:"matrix,omitempty"`
+	Webhook  *WebhookConfig  `json:"webhook,omitempty"`
+}
+
+// SlackConfig configura SlackNotifier.
+type SlackConfig struct {
+	WebhookURL string `json:"webhook_url"`
+}
+
+// DiscordConfig configura DiscordNotifier.
+type DiscordConfig struct {
+	WebhookURL string `json:"webhook_url"`
+}
+
+// TelegramConfig configura TelegramNotifier.
+type TelegramConfig struct {
+	BotToken string `json:"bot_token"`
+	ChatID   string `json:"chat_id"`
+}
+
+// MatrixConfig configura MatrixNotifier.
+type MatrixConfig struct {
+	Homeserver  string `json:"homeserver"`
+	AccessToken string `json:"access_token"`
+	RoomID      string `json:"room_id"`
+}
+
+// WebhookConfig configura WebhookNotifier.
+type WebhookConfig struct {
+	URL      string `json:"url"`
+	Template string `json:"template,omitempty"`
+}
+
+// LoadConfig arma la configuración a partir de NOTIFIER_CONFIG_FILE (un
+// archivo JSON) si está definida, o de las variables de entorno
+// individuales en caso contrario.
+func LoadConfig() (Config, error) {
+	if path := os.Getenv("NOTIFIER_CONFIG_FILE"); path != "" {
+		return loadConfigFile(path)
+	}
+	return configFromEnv(), nil
+}
+
+func loadConfigFile(path string) (Config, error) {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func configFromEnv() Config {
+	var cfg Config
+
+	if url := os.Getenv("SLACK_WEBHOOK_URL"); url != "" {
+		cfg.Slack = &SlackConfig{WebhookURL: url}
+	}
+	if url := os.Getenv("DISCORD_WEBHOOK_URL"); url != "" {
+		cfg.Discord = &DiscordConfig{WebhookURL: url}
+	}
+	if token, chatID := os.Getenv("TELEGRAM_BOT_TOKEN"), os.Getenv("TELEGRAM_CHAT_ID"); token != "" && chatID != "" {
+		cfg.Telegram = &TelegramConfig{BotToken: token, ChatID: chatID}
+	}
+	if hs, token, room := os.Getenv("MATRIX_HOMESERVER"), os.Getenv("MATRIX_ACCESS_TOKEN"), os.Getenv("MATRIX_ROOM_ID"); hs != "" && token != "" && room != "" {
+		cfg.Matrix = &MatrixConfig{Homeserver: hs, AccessToken: token, RoomID: room}
+	}
+	if url := os.Getenv("WEBHOOK_URL"); url != "" {
+		cfg.Webhook = &WebhookConfig{URL: url, Template: os.Getenv("WEBHOOK_TEMPLATE")}
+	}
+
+	return cfg
+}
+
+// Build construye los Notifier habilitados en cfg, en orden estable
+// (Slack, Discord, Telegram, Matrix, webhook genérico).
+func (cfg Config) Build() ([]Notifier, error) {
+	var notifiers []Notifier
+
+	if cfg.Slack != nil {
+		notifiers = append(notifiers, NewSlackNotifier(cfg.Slack.WebhookURL))
+	}
+	if cfg.Discord != nil {
+		notifiers = append(notifiers, NewDiscordNotifier(cfg.Discord.WebhookURL))
+	}
+	if cfg.Telegram != nil {
+		notifiers = append(notifiers, NewTelegramNotifier(cfg.Telegram.BotToken, cfg.Telegram.ChatID))
+	}
+	if cfg.Matrix != nil {
+		notifiers = append(notifiers, NewMatrixNotifier(cfg.Matrix.Homeserver, cfg.Matrix.AccessToken, cfg.Matrix.RoomID))
+	}
+	if cfg.Webhook != nil {
+		webhook, err := NewWebhookNotifier(cfg.Webhook.URL, cfg.Webhook.Template)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, webhook)
+	}
+
+	return notifiers, nil
+}
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+)
+
+// DiscordNotifier publica el contacto como un embed en un canal de Discord
+// vía un webhook entrante.
+type DiscordNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// NewDiscordNotifier crea un Notifier que publica en el webhook entrante dado.
+func NewDiscordNotifier(webhookURL string) *DiscordNotifier {
+	return &DiscordNotifier{WebhookURL: webhookURL, Client: &http.Client{}}
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func (n *DiscordNotifier) Notify(ctx context.Context, data ContactData, clientIP string) error {
+	msg := discordMessage{
+		Embeds: []discordEmbed{
+			{
+				Title:       "Nuevo mensaje de contacto",
+				Description: data.Message,
+				Color:       0x4f46e5,
+				Fields: []discordField{
+					{Name: "Nombre", Value: data.Name, Inline: true},
+					{Name: "Email", Value: data.Email, Inline: true},
+					{Name: "IP", Value: clientIP, Inline: true},
+				},
+			},
+		},
+	}
+
+	return postJSON(ctx, n.Client, n.WebhookURL, msg)
+}
@@ -0,0 +1,37 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout limita cuánto puede tardar cada notificador individual, para
+// que un backend caído no retrase a los demás.
+const DefaultTimeout = 5 * time.Second
+
+// Dispatch envía data a todos los notifiers en paralelo, cada uno con su
+// propio timeout, y devuelve el error de cada uno (nil si tuvo éxito) en el
+// mismo orden que notifiers. El fallo de un backend nunca cancela a los
+// demás; el llamador decide qué hacer con los errores (típicamente, solo
+// registrarlos).
+func Dispatch(ctx context.Context, notifiers []Notifier, data ContactData, clientIP string, timeout time.Duration) []error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	errs := make([]error, len(notifiers))
+	var wg sync.WaitGroup
+	for i, n := range notifiers {
+		wg.Add(1)
+		go func(i int, n Notifier) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			errs[i] = n.Notify(ctx, data, clientIP)
+		}(i, n)
+	}
+	wg.Wait()
+
+	return errs
+}
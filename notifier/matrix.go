@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MatrixNotifier publica el contacto como un evento m.room.message en una
+// sala de Matrix vía la API client-server.
+type MatrixNotifier struct {
+	Homeserver  string
+	AccessToken string
+	RoomID      string
+	Client      *http.Client
+}
+
+// NewMatrixNotifier crea un Notifier que publica en roomID del homeserver
+// dado, autenticado con accessToken.
+func NewMatrixNotifier(homeserver, accessToken, roomID string) *MatrixNotifier {
+	return &MatrixNotifier{
+		Homeserver:  strings.TrimSuffix(homeserver, "/"),
+		AccessToken: accessToken,
+		RoomID:      roomID,
+		Client:      &http.Client{},
+	}
+}
+
+func (n *MatrixNotifier) Notify(ctx context.Context, data ContactData, clientIP string) error {
+	body := fmt.Sprintf("Nuevo mensaje de contacto\nNombre: %s\nEmail: %s\nIP: %s\n\n%s",
+		data.Name, data.Email, clientIP, data.Message)
+
+	// Matrix requiere un txn_id único por petición PUT para que los
+	// reintentos del cliente no dupliquen el evento.
+	txnID := fmt.Sprintf("%d", time.Now().UnixNano())
+	url := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s?access_token=%s",
+		n.Homeserver, n.RoomID, txnID, n.AccessToken)
+
+	return putJSON(ctx, n.Client, url, map[string]string{
+		"msgtype": "m.text",
+		"body":    body,
+	})
+}
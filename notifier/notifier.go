@@ -0,0 +1,20 @@
+// Package notifier define el abstracto Notifier y sus backends concretos
+// (Slack, Discord, Telegram, Matrix, webhook genérico) usados para avisar a
+// los operadores de un nuevo mensaje de contacto por canales alternativos al
+// correo SMTP.
+package notifier
+
+import "context"
+
+// ContactData es la información del formulario de contacto que se envía a
+// cada backend de notificación.
+type ContactData struct {
+	Name    string
+	Email   string
+	Message string
+}
+
+// Notifier envía el aviso de un contacto a un backend externo.
+type Notifier interface {
+	Notify(ctx context.Context, data ContactData, clientIP string) error
+}
@@ -0,0 +1,183 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlackNotifierPayload(t *testing.T) {
+	var got slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n := NewSlackNotifier(server.URL)
+	if err := n.Notify(context.Background(), ContactData{Name: "Juan", Email: "juan@example.com", Message: "Hola"}, "1.2.3.4"); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if len(got.Attachments) != 1 || got.Attachments[0].Text != "Hola" {
+		t.Errorf("payload de Slack inesperado: %+v", got)
+	}
+}
+
+func TestDiscordNotifierPayload(t *testing.T) {
+	var got discordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n := NewDiscordNotifier(server.URL)
+	if err := n.Notify(context.Background(), ContactData{Name: "Juan", Email: "juan@example.com", Message: "Hola"}, "1.2.3.4"); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if len(got.Embeds) != 1 || got.Embeds[0].Description != "Hola" {
+		t.Errorf("payload de Discord inesperado: %+v", got)
+	}
+}
+
+func TestTelegramNotifierPayload(t *testing.T) {
+	var got map[string]string
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n := NewTelegramNotifier("test-token", "chat-1")
+	n.Client = server.Client()
+
+	// Redirigir la URL absoluta de la Bot API al servidor de prueba.
+	notifyViaTestServer(t, n, server.URL)
+
+	if !strings.Contains(gotPath, "test-token") {
+		t.Errorf("la ruta debería incluir el bot token, se obtuvo %q", gotPath)
+	}
+	if got["chat_id"] != "chat-1" {
+		t.Errorf("chat_id incorrecto: %q", got["chat_id"])
+	}
+}
+
+// notifyViaTestServer llama a Notify apuntando a serverURL en lugar del host
+// real de la API, reescribiendo las peticiones salientes del cliente HTTP.
+func notifyViaTestServer(t *testing.T, n *TelegramNotifier, serverURL string) {
+	t.Helper()
+	n.Client = &http.Client{Transport: rewriteHostTransport{target: serverURL}}
+	if err := n.Notify(context.Background(), ContactData{Name: "Juan", Email: "juan@example.com", Message: "Hola"}, "1.2.3.4"); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+}
+
+type rewriteHostTransport struct {
+	target string
+}
+
+func (rt rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, err := req.URL.Parse(rt.target + req.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	req2 := req.Clone(req.Context())
+	req2.URL = target
+	req2.Host = target.Host
+	return http.DefaultTransport.RoundTrip(req2)
+}
+
+func TestWebhookNotifierRendersTemplate(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, r.ContentLength)
+		r.Body.Read(buf)
+		gotBody = string(buf)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n, err := NewWebhookNotifier(server.URL, `{"who":"{{.Name}}","ip":"{{.ClientIP}}"}`)
+	if err != nil {
+		t.Fatalf("NewWebhookNotifier() error = %v", err)
+	}
+
+	if err := n.Notify(context.Background(), ContactData{Name: "Juan"}, "1.2.3.4"); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if !strings.Contains(gotBody, `"who":"Juan"`) || !strings.Contains(gotBody, `"ip":"1.2.3.4"`) {
+		t.Errorf("cuerpo del webhook inesperado: %q", gotBody)
+	}
+}
+
+func TestWebhookNotifierInvalidTemplate(t *testing.T) {
+	if _, err := NewWebhookNotifier("http://example.com", "{{"); err == nil {
+		t.Error("se esperaba un error al parsear una plantilla inválida")
+	}
+}
+
+func TestDispatchAggregatesErrorsWithoutStopping(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	succeeding := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer succeeding.Close()
+
+	notifiers := []Notifier{
+		NewSlackNotifier(failing.URL),
+		NewSlackNotifier(succeeding.URL),
+	}
+
+	errs := Dispatch(context.Background(), notifiers, ContactData{Name: "Juan"}, "1.2.3.4", time.Second)
+
+	if len(errs) != 2 {
+		t.Fatalf("se esperaban 2 resultados, se obtuvieron %d", len(errs))
+	}
+	if errs[0] == nil {
+		t.Error("se esperaba un error para el notificador que falla")
+	}
+	if errs[1] != nil {
+		t.Errorf("no se esperaba error para el notificador que funciona: %v", errs[1])
+	}
+}
+
+func TestConfigFromEnvBuildsEnabledNotifiers(t *testing.T) {
+	t.Setenv("SLACK_WEBHOOK_URL", "https://hooks.slack.test/abc")
+	t.Setenv("DISCORD_WEBHOOK_URL", "")
+	t.Setenv("TELEGRAM_BOT_TOKEN", "")
+	t.Setenv("TELEGRAM_CHAT_ID", "")
+	t.Setenv("MATRIX_HOMESERVER", "")
+	t.Setenv("MATRIX_ACCESS_TOKEN", "")
+	t.Setenv("MATRIX_ROOM_ID", "")
+	t.Setenv("WEBHOOK_URL", "")
+	t.Setenv("NOTIFIER_CONFIG_FILE", "")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	notifiers, err := cfg.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if len(notifiers) != 1 {
+		t.Fatalf("se esperaba 1 notificador habilitado, se obtuvieron %d", len(notifiers))
+	}
+	if _, ok := notifiers[0].(*SlackNotifier); !ok {
+		t.Errorf("se esperaba un *SlackNotifier, se obtuvo %T", notifiers[0])
+	}
+}
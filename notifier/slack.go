@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// SlackNotifier publica el contacto en un canal de Slack vía un webhook
+// entrante, como un attachment en el formato heredado de Slack (color,
+// título, texto y campos), no como bloques de Block Kit.
+type SlackNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// NewSlackNotifier crea un Notifier que publica en el webhook entrante dado.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{WebhookURL: webhookURL, Client: &http.Client{}}
+}
+
+type slackMessage struct {
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color     string       `json:"color"`
+	Title     string       `json:"title"`
+	Text      string       `json:"text"`
+	Fields    []slackField `json:"fields"`
+	Timestamp int64        `json:"ts"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, data ContactData, clientIP string) error {
+	msg := slackMessage{
+		Attachments: []slackAttachment{
+			{
+				Color: "good",
+				Title: "Nuevo mensaje de contacto",
+				Text:  data.Message,
+				Fields: []slackField{
+					{Title: "Nombre", Value: data.Name, Short: true},
+					{Title: "Email", Value: data.Email, Short: true},
+					{Title: "IP", Value: clientIP, Short: true},
+				},
+				Timestamp: time.Now().Unix(),
+			},
+		},
+	}
+
+	return postJSON(ctx, n.Client, n.WebhookURL, msg)
+}
@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// TelegramNotifier publica el contacto en un chat de Telegram usando la Bot API.
+type TelegramNotifier struct {
+	BotToken string
+	ChatID   string
+	Client   *http.Client
+}
+
+// NewTelegramNotifier crea un Notifier que publica en el chat chatID usando
+// el bot identificado por botToken.
+func NewTelegramNotifier(botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{BotToken: botToken, ChatID: chatID, Client: &http.Client{}}
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, data ContactData, clientIP string) error {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", n.BotToken)
+	text := fmt.Sprintf("Nuevo mensaje de contacto\nNombre: %s\nEmail: %s\nIP: %s\n\n%s",
+		data.Name, data.Email, clientIP, data.Message)
+
+	return postJSON(ctx, n.Client, url, map[string]string{
+		"chat_id": n.ChatID,
+		"text":    text,
+	})
+}
@@ -0,0 +1,56 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	texttemplate "text/template"
+)
+
+// DefaultWebhookTemplate produce un JSON simple con los campos del contacto
+// cuando no se especifica una plantilla propia.
+const DefaultWebhookTemplate = `{"name":"{{.Name}}","email":"{{.Email}}","message":"{{.Message}}","client_ip":"{{.ClientIP}}"}`
+
+// WebhookNotifier publica el contacto en un endpoint HTTP arbitrario,
+// serializándolo con una plantilla JSON configurable.
+type WebhookNotifier struct {
+	URL      string
+	Template *texttemplate.Template
+	Client   *http.Client
+}
+
+// NewWebhookNotifier compila tmpl (o DefaultWebhookTemplate si está vacío) y
+// construye un Notifier que publica en url.
+func NewWebhookNotifier(url, tmpl string) (*WebhookNotifier, error) {
+	if tmpl == "" {
+		tmpl = DefaultWebhookTemplate
+	}
+
+	parsed, err := texttemplate.New("webhook").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("error al parsear la plantilla del webhook: %v", err)
+	}
+
+	return &WebhookNotifier{URL: url, Template: parsed, Client: &http.Client{}}, nil
+}
+
+type webhookPayload struct {
+	ContactData
+	ClientIP string
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, data ContactData, clientIP string) error {
+	var body bytes.Buffer
+	if err := n.Template.Execute(&body, webhookPayload{ContactData: data, ClientIP: clientIP}); err != nil {
+		return fmt.Errorf("error al renderizar la plantilla del webhook: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doAndCheckStatus(n.Client, req)
+}
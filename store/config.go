@@ -0,0 +1,18 @@
+package store
+
+import "os"
+
+// FromEnv construye el SubmissionStore configurado por variables de
+// entorno: STORE_DSN activa el backend Postgres; en su ausencia se usa
+// SQLite en STORE_SQLITE_PATH (por defecto "submissions.db").
+func FromEnv() (SubmissionStore, error) {
+	if dsn := os.Getenv("STORE_DSN"); dsn != "" {
+		return NewPostgresStore(dsn)
+	}
+
+	path := os.Getenv("STORE_SQLITE_PATH")
+	if path == "" {
+		path = "submissions.db"
+	}
+	return NewSQLiteStore(path)
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// PostgresStore implementa SubmissionStore sobre PostgreSQL vía el driver
+// stdlib de pgx, para despliegues que prefieren una base de datos
+// gestionada en lugar del archivo SQLite local.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore abre la conexión descrita por dsn y aplica las
+// migraciones necesarias.
+func NewPostgresStore(dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error al abrir la base de datos: %v", err)
+	}
+
+	s := &PostgresStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *PostgresStore) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS submissions (
+			id                BIGSERIAL PRIMARY KEY,
+			created_at        TIMESTAMPTZ NOT NULL,
+			name              TEXT NOT NULL,
+			email             TEXT NOT NULL,
+			message           TEXT NOT NULL,
+			client_ip         TEXT NOT NULL,
+			user_agent        TEXT NOT NULL DEFAULT '',
+			origin            TEXT NOT NULL DEFAULT '',
+			notified_slack_at TIMESTAMPTZ,
+			replied_at        TIMESTAMPTZ
+		);
+		CREATE INDEX IF NOT EXISTS idx_submissions_created_at ON submissions(created_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("error al migrar la base de datos: %v", err)
+	}
+	return nil
+}
+
+// Save persiste sub. Si CreatedAt está vacío se rellena con time.Now().
+func (s *PostgresStore) Save(sub *Submission) error {
+	if sub.CreatedAt.IsZero() {
+		sub.CreatedAt = time.Now()
+	}
+
+	err := s.db.QueryRow(
+		`INSERT INTO submissions (created_at, name, email, message, client_ip, user_agent, origin, notified_slack_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		sub.CreatedAt, sub.Name, sub.Email, sub.Message, sub.ClientIP, sub.UserAgent, sub.Origin, sub.NotifiedSlackAt,
+	).Scan(&sub.ID)
+	if err != nil {
+		return fmt.Errorf("error al guardar la submission: %v", err)
+	}
+	return nil
+}
+
+// List devuelve las submissions que cumplen filter, más recientes primero.
+func (s *PostgresStore) List(filter Filter) ([]Submission, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	where := "created_at >= $1"
+	args := []interface{}{filter.Since}
+	if filter.Query != "" {
+		where += " AND (name ILIKE $2 OR email ILIKE $2 OR message ILIKE $2)"
+		args = append(args, "%"+filter.Query+"%")
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(
+		`SELECT id, created_at, name, email, message, client_ip, user_agent, origin, notified_slack_at, replied_at
+		 FROM submissions WHERE %s ORDER BY created_at DESC LIMIT $%d`, where, len(args))
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar submissions: %v", err)
+	}
+	defer rows.Close()
+
+	var submissions []Submission
+	for rows.Next() {
+		sub, err := scanSubmission(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer submission: %v", err)
+		}
+		submissions = append(submissions, sub)
+	}
+	return submissions, rows.Err()
+}
+
+// Get devuelve la submission con el ID dado, o ErrNotFound si no existe.
+func (s *PostgresStore) Get(id int64) (Submission, error) {
+	row := s.db.QueryRow(
+		`SELECT id, created_at, name, email, message, client_ip, user_agent, origin, notified_slack_at, replied_at
+		 FROM submissions WHERE id = $1`, id,
+	)
+
+	sub, err := scanSubmission(row)
+	if err == sql.ErrNoRows {
+		return Submission{}, ErrNotFound
+	}
+	if err != nil {
+		return Submission{}, fmt.Errorf("error al obtener la submission: %v", err)
+	}
+	return sub, nil
+}
+
+// MarkReplied marca la submission id como respondida en repliedAt.
+func (s *PostgresStore) MarkReplied(id int64, repliedAt time.Time) error {
+	result, err := s.db.Exec(`UPDATE submissions SET replied_at = $1 WHERE id = $2`, repliedAt, id)
+	if err != nil {
+		return fmt.Errorf("error al marcar la submission como respondida: %v", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al verificar la actualización: %v", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Close cierra la conexión a la base de datos.
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
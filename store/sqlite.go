@@ -0,0 +1,167 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implementa SubmissionStore sobre modernc.org/sqlite, sin
+// dependencia de CGO, apto para despliegues estándar.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore abre (o crea) la base de datos en path y aplica las
+// migraciones necesarias.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("error al abrir la base de datos: %v", err)
+	}
+
+	s := &SQLiteStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *SQLiteStore) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS submissions (
+			id                INTEGER PRIMARY KEY AUTOINCREMENT,
+			created_at        DATETIME NOT NULL,
+			name              TEXT NOT NULL,
+			email             TEXT NOT NULL,
+			message           TEXT NOT NULL,
+			client_ip         TEXT NOT NULL,
+			user_agent        TEXT NOT NULL DEFAULT '',
+			origin            TEXT NOT NULL DEFAULT '',
+			notified_slack_at DATETIME,
+			replied_at        DATETIME
+		);
+		CREATE INDEX IF NOT EXISTS idx_submissions_created_at ON submissions(created_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("error al migrar la base de datos: %v", err)
+	}
+	return nil
+}
+
+// Save persiste sub. Si CreatedAt está vacío se rellena con time.Now().
+func (s *SQLiteStore) Save(sub *Submission) error {
+	if sub.CreatedAt.IsZero() {
+		sub.CreatedAt = time.Now()
+	}
+
+	result, err := s.db.Exec(
+		`INSERT INTO submissions (created_at, name, email, message, client_ip, user_agent, origin, notified_slack_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		sub.CreatedAt, sub.Name, sub.Email, sub.Message, sub.ClientIP, sub.UserAgent, sub.Origin, sub.NotifiedSlackAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error al guardar la submission: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("error al obtener el ID de la submission: %v", err)
+	}
+	sub.ID = id
+	return nil
+}
+
+// List devuelve las submissions que cumplen filter, más recientes primero.
+func (s *SQLiteStore) List(filter Filter) ([]Submission, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	where := "created_at >= ?"
+	args := []interface{}{filter.Since}
+	if filter.Query != "" {
+		where += " AND (name LIKE ? OR email LIKE ? OR message LIKE ?)"
+		like := "%" + filter.Query + "%"
+		args = append(args, like, like, like)
+	}
+	args = append(args, limit)
+
+	rows, err := s.db.Query(fmt.Sprintf(
+		`SELECT id, created_at, name, email, message, client_ip, user_agent, origin, notified_slack_at, replied_at
+		 FROM submissions WHERE %s ORDER BY created_at DESC LIMIT ?`, where), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar submissions: %v", err)
+	}
+	defer rows.Close()
+
+	var submissions []Submission
+	for rows.Next() {
+		sub, err := scanSubmission(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer submission: %v", err)
+		}
+		submissions = append(submissions, sub)
+	}
+	return submissions, rows.Err()
+}
+
+// Get devuelve la submission con el ID dado, o ErrNotFound si no existe.
+func (s *SQLiteStore) Get(id int64) (Submission, error) {
+	row := s.db.QueryRow(
+		`SELECT id, created_at, name, email, message, client_ip, user_agent, origin, notified_slack_at, replied_at
+		 FROM submissions WHERE id = ?`, id,
+	)
+
+	sub, err := scanSubmission(row)
+	if err == sql.ErrNoRows {
+		return Submission{}, ErrNotFound
+	}
+	if err != nil {
+		return Submission{}, fmt.Errorf("error al obtener la submission: %v", err)
+	}
+	return sub, nil
+}
+
+// MarkReplied marca la submission id como respondida en repliedAt.
+func (s *SQLiteStore) MarkReplied(id int64, repliedAt time.Time) error {
+	result, err := s.db.Exec(`UPDATE submissions SET replied_at = ? WHERE id = ?`, repliedAt, id)
+	if err != nil {
+		return fmt.Errorf("error al marcar la submission como respondida: %v", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al verificar la actualización: %v", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Close cierra la conexión a la base de datos.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// rowScanner abstrae *sql.Row y *sql.Rows para que scanSubmission sirva
+// tanto a Get como a List.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubmission(row rowScanner) (Submission, error) {
+	var sub Submission
+	if err := row.Scan(
+		&sub.ID, &sub.CreatedAt, &sub.Name, &sub.Email, &sub.Message,
+		&sub.ClientIP, &sub.UserAgent, &sub.Origin, &sub.NotifiedSlackAt, &sub.RepliedAt,
+	); err != nil {
+		return Submission{}, err
+	}
+	return sub, nil
+}
@@ -0,0 +1,54 @@
+// Package store persiste las submisiones del formulario de contacto en un
+// backend durable (SQLite o Postgres) para que dejen de ser fire-and-forget:
+// se pueden listar, consultar por ID y marcar como respondidas desde el
+// panel de administración.
+package store
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound indica que no existe una submission con el ID solicitado.
+var ErrNotFound = errors.New("store: submission no encontrada")
+
+const defaultListLimit = 50
+
+// Submission es una entrada persistida del formulario de contacto.
+type Submission struct {
+	ID              int64      `json:"id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	Message         string     `json:"message"`
+	ClientIP        string     `json:"client_ip"`
+	UserAgent       string     `json:"user_agent"`
+	Origin          string     `json:"origin"`
+	NotifiedSlackAt *time.Time `json:"notified_slack_at,omitempty"`
+	RepliedAt       *time.Time `json:"replied_at,omitempty"`
+}
+
+// Filter restringe los resultados de List.
+type Filter struct {
+	// Since solo incluye submissions creadas en o después de este instante.
+	Since time.Time
+	// Query, si no está vacío, filtra por coincidencia parcial en nombre,
+	// email o mensaje.
+	Query string
+	// Limit acota el número de resultados; <= 0 usa defaultListLimit.
+	Limit int
+}
+
+// SubmissionStore persiste y consulta las submisiones del formulario de
+// contacto.
+type SubmissionStore interface {
+	// Save persiste sub y completa su ID y CreatedAt si no vienen fijados.
+	Save(sub *Submission) error
+	// List devuelve las submissions que cumplen filter, más recientes primero.
+	List(filter Filter) ([]Submission, error)
+	// Get devuelve la submission con el ID dado, o ErrNotFound si no existe.
+	Get(id int64) (Submission, error)
+	// MarkReplied marca la submission como respondida en repliedAt.
+	MarkReplied(id int64, repliedAt time.Time) error
+	Close() error
+}
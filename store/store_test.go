@@ -0,0 +1,94 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	s, err := NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestSQLiteStoreSaveAssignsIDAndCreatedAt(t *testing.T) {
+	s := newTestStore(t)
+
+	sub := Submission{Name: "Ana", Email: "ana@example.com", Message: "Hola", ClientIP: "127.0.0.1"}
+	if err := s.Save(&sub); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if sub.ID == 0 {
+		t.Error("Save() no asignó un ID")
+	}
+	if sub.CreatedAt.IsZero() {
+		t.Error("Save() no asignó CreatedAt")
+	}
+}
+
+func TestSQLiteStoreGetReturnsErrNotFound(t *testing.T) {
+	s := newTestStore(t)
+
+	if _, err := s.Get(999); err != ErrNotFound {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLiteStoreListFiltersBySinceAndQuery(t *testing.T) {
+	s := newTestStore(t)
+
+	old := Submission{Name: "Vieja", Email: "vieja@example.com", Message: "mensaje viejo", ClientIP: "127.0.0.1", CreatedAt: time.Now().Add(-48 * time.Hour)}
+	recent := Submission{Name: "Reciente", Email: "reciente@example.com", Message: "mensaje nuevo", ClientIP: "127.0.0.1"}
+	for _, sub := range []Submission{old, recent} {
+		sub := sub
+		if err := s.Save(&sub); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	results, err := s.List(Filter{Since: time.Now().Add(-24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "Reciente" {
+		t.Errorf("List() = %+v, want solo la submission reciente", results)
+	}
+
+	results, err = s.List(Filter{Query: "vieja"})
+	if err != nil {
+		t.Fatalf("List() con query error = %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "Vieja" {
+		t.Errorf("List() con query = %+v, want solo la submission 'Vieja'", results)
+	}
+}
+
+func TestSQLiteStoreMarkReplied(t *testing.T) {
+	s := newTestStore(t)
+
+	sub := Submission{Name: "Ana", Email: "ana@example.com", Message: "Hola", ClientIP: "127.0.0.1"}
+	if err := s.Save(&sub); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	repliedAt := time.Now()
+	if err := s.MarkReplied(sub.ID, repliedAt); err != nil {
+		t.Fatalf("MarkReplied() error = %v", err)
+	}
+
+	got, err := s.Get(sub.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.RepliedAt == nil {
+		t.Error("Get() no refleja MarkReplied")
+	}
+
+	if err := s.MarkReplied(999, repliedAt); err != ErrNotFound {
+		t.Errorf("MarkReplied() en ID inexistente error = %v, want ErrNotFound", err)
+	}
+}